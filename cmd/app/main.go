@@ -2,69 +2,183 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/AntonTsoy/review-pull-request-service/internal/auth"
 	"github.com/AntonTsoy/review-pull-request-service/internal/config"
 	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+	"github.com/AntonTsoy/review-pull-request-service/internal/metrics"
 	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository/memory"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository/postgres"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository/sqlite"
 	"github.com/AntonTsoy/review-pull-request-service/internal/service"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/handlers"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/middleware"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/server"
 )
 
+var errNoAdminToken = errors.New("ADMIN_TOKEN is not set and there are no active ADMIN tokens: " +
+	"set ADMIN_TOKEN to a random value (for example, openssl rand -hex 32), configure JWT_JWKS_FILE or JWT_JWKS_URL, " +
+	"or disable authentication with AUTH_ENABLED=false for local development")
+
 func main() {
-	log.Println("Initialize application...")
+	logging.Setup(slog.LevelInfo)
+	slog.Info("Initialize application...")
 
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal(err)
+		fatal("load config", err)
+	}
+
+	logging.Setup(cfg.LogLevel)
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg, os.Args[2:]); err != nil {
+			fatal("migrate", err)
+		}
+		return
+	}
+
+	if err := migrateOnStart(cfg); err != nil {
+		fatal("migrate on start", err)
 	}
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	db, err := database.New(ctx, cfg)
+	shutdownTracing, err := tracing.Setup(ctx, cfg)
+	if err != nil {
+		fatal("set up tracing", err)
+	}
+
+	db, repository, err := openStorage(ctx, cfg)
 	if err != nil {
-		log.Fatalf("failed to create DB instance: %v", err)
+		fatal("open storage", err)
 	}
 	defer db.Close()
 
-	if err = db.HealthCheck(ctx); err != nil {
-		log.Fatalf("failed to open connection with database: %v", err)
+	if pg, ok := db.(*database.Database); ok {
+		metrics.RegisterPool(pg.Pool())
 	}
-	log.Println("DB connection opened!")
 
-	repository := repository.NewRepository()
+	service := service.NewService(db, repository, cfg)
+
+	go service.EventDispatcher.Run(ctx)
+
+	handlers := handlers.NewHandlers(service, cfg)
 
-	service := service.NewService(db, repository)
+	var authenticators []middleware.Authenticator
+	if cfg.AuthEnabled {
+		verifier, err := auth.NewJWTVerifier(ctx, cfg)
+		if err != nil {
+			fatal("set up JWT authentication", err)
+		}
+		if verifier != nil {
+			authenticators = append(authenticators, verifier)
+			slog.Info("JWT authentication is enabled")
+		}
 
-	handlers := handlers.NewHandlers(service)
+		authenticators = append(authenticators, service.AuthService)
+		if cfg.AdminToken == "" {
+			// без SSO и без единого администраторского токена API было бы закрыто для всех
+			if verifier == nil {
+				ok, err := service.AuthService.HasAdminToken(ctx)
+				if err != nil {
+					fatal("check API tokens", err)
+				}
+				if !ok {
+					fatal("set up authentication", errNoAdminToken)
+				}
+			}
+			slog.Warn("ADMIN_TOKEN is not set, only tokens issued earlier will be accepted")
+		}
+	} else {
+		slog.Warn("Authentication is disabled, API is open to everyone")
+	}
 
-	server := server.New(handlers)
+	server := server.New(handlers, authenticators...)
 
 	server.SetSwagger()
-	log.Println("Try to use swagger on http://127.0.0.1:8080/docs")
+	server.SetMetrics()
+	slog.Info("Try to use swagger on http://127.0.0.1:8080/docs")
 
 	go func() {
 		if err := server.Start(":8080"); err != nil {
-			log.Printf("server error: %v", err)
+			slog.Error("server error", logging.Error(err))
 		}
 	}()
 
-	sig := <-ctx.Done()
-	log.Printf("Received signal: %v. Starting graceful shutdown...\n", sig)
+	<-ctx.Done()
+	// повторный сигнал завершит процесс сразу, не дожидаясь конца drain
+	cancel()
+	slog.Info("Received shutdown signal, starting graceful shutdown...")
+
+	service.HealthService.SetShuttingDown()
+	if cfg.ShutdownDrainDelay > 0 {
+		slog.Info("Readiness is DOWN, waiting for load balancers to drain", slog.String("delay", cfg.ShutdownDrainDelay.String()))
+		time.Sleep(cfg.ShutdownDrainDelay)
+	}
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Printf("Error during shutdown: %v", err)
+		slog.Error("Error during shutdown", logging.Error(err))
 		return
 	}
 
-	log.Println("Server stopped gracefully")
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Error flushing traces", logging.Error(err))
+	}
+
+	slog.Info("Server stopped gracefully")
+}
+
+// fatal логирует ошибку запуска и завершает процесс.
+func fatal(msg string, err error) {
+	slog.Error(msg, logging.Error(err))
+	os.Exit(1)
+}
+
+func openStorage(ctx context.Context, cfg *config.Config) (database.Storage, *repository.Repository, error) {
+	switch cfg.Storage {
+	case config.StorageMemory:
+		slog.Warn("Using in-memory storage, data will be lost on restart")
+		return memory.NewStore(), memory.NewRepository(), nil
+	case config.StorageSQLite:
+		db, err := database.NewSQLite(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create DB instance: %w", err)
+		}
+
+		if err = db.HealthCheck(ctx); err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("failed to open sqlite database: %w", err)
+		}
+		slog.Info("SQLite database opened", slog.String("path", cfg.SQLitePath))
+
+		return db, sqlite.NewRepository(), nil
+	}
+
+	db, err := database.New(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create DB instance: %w", err)
+	}
+
+	if err = db.HealthCheck(ctx); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to open connection with database: %w", err)
+	}
+	slog.Info("DB connection opened")
+
+	return db, postgres.NewRepository(), nil
 }
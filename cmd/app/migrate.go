@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+)
+
+const migrateUsage = "usage: app migrate up | down [N] | status"
+
+// runMigrate выполняет подкоманду `app migrate up|down [N]|status`.
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := database.NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		return migrator.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid number of steps %q: %w", args[1], err)
+			}
+		}
+		return migrator.Down(steps)
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("version: %d\ndirty: %t\n", status.Version, status.Dirty)
+		if len(status.Pending) == 0 {
+			fmt.Println("pending: none")
+		}
+		for _, version := range status.Pending {
+			fmt.Printf("pending: %d\n", version)
+		}
+		return nil
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+// migrateOnStart применяет миграции перед запуском сервера; хранилищу в памяти они не нужны.
+func migrateOnStart(cfg *config.Config) error {
+	if !cfg.MigrateOnStart || cfg.Storage == config.StorageMemory {
+		return nil
+	}
+
+	migrator, err := database.NewMigrator(cfg)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	return migrator.Up()
+}
@@ -7,6 +7,26 @@ var (
 	ErrTeamExists        = errors.New("team_name already exists")
 	ErrPullRequestExists = errors.New("PR id already exists")
 	ErrPullRequestMerged = errors.New("cannot reassign on merged PR")
+	ErrPullRequestClosed = errors.New("PR is closed")
+	ErrAlreadyMerged     = errors.New("PR is already merged")
 	ErrNotAssigned       = errors.New("reviewer is not assigned to this PR")
 	ErrNoCandidate       = errors.New("no active replacement candidate in team")
+	ErrUnknownStrategy   = errors.New("unknown reviewer strategy")
+	ErrInvalidReviewers  = errors.New("reviewers_required must be between 1 and 10")
+	ErrInvalidPeriod     = errors.New("period start must be before its end")
+	ErrInvalidReviewCap  = errors.New("max_open_reviews must not be negative")
+	ErrNothingToUpdate   = errors.New("no fields to update")
+	ErrNotApproved       = errors.New("not all assigned reviewers have approved the PR")
+	ErrInvalidReview     = errors.New("review state must be APPROVED or CHANGES_REQUESTED")
+	ErrInvalidSignature  = errors.New("invalid webhook signature")
+	ErrInvalidToken      = errors.New("invalid webhook token")
+	ErrUnknownProvider   = errors.New("unknown login provider")
+	ErrInvalidURL        = errors.New("url must be an absolute http or https URL")
+	ErrNoEvents          = errors.New("at least one event type is required")
+	ErrUnknownEvent      = errors.New("unknown event type")
+	ErrUnauthenticated   = errors.New("missing or invalid access token")
+	ErrForbidden         = errors.New("access token does not permit this operation")
+	ErrUnknownRole       = errors.New("token role must be ADMIN or USER")
+	ErrInvalidTokenName  = errors.New("token name must be 1 to 100 characters")
+	ErrUnknownEntity     = errors.New("entity_type must be TEAM, USER or PULL_REQUEST")
 )
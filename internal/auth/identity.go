@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+)
+
+// Identity — кто вызывает API: субъект токена и его роль в сервисе.
+// Для JWT субъект — это user_id пользователя, для статических токенов — имя токена.
+type Identity struct {
+	Subject string
+	Role    models.Role
+	// UserID заполнен, только если токен выдан конкретному пользователю (JWT от SSO).
+	UserID string
+}
+
+type identityKey struct{}
+
+// ContextKey — ключ, под которым Identity лежит в контексте запроса. Middleware кладёт её
+// в fiber.Ctx.Locals, поэтому она видна и через c.Context(), и через c.UserContext().
+var ContextKey = identityKey{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, ContextKey, identity)
+}
+
+// FromContext возвращает личность вызывающего или nil, если запрос не аутентифицирован.
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(ContextKey).(*Identity)
+	return identity
+}
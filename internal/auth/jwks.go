@@ -0,0 +1,295 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	jwksTimeout = 10 * time.Second
+	// minKeyRefresh ограничивает перечитывание JWKS из-за неизвестного kid,
+	// чтобы поток токенов с мусорным kid не превратился в поток запросов к SSO.
+	minKeyRefresh = time.Minute
+	// maxKeyBackoff — предел паузы между попытками перечитать JWKS, пока SSO недоступен.
+	maxKeyBackoff = 15 * time.Minute
+)
+
+var errUnknownKey = errors.New("unknown signing key")
+
+// KeySet — открытые ключи подписи из JWKS, по kid. Набор перечитывается раз в refresh
+// и при встрече неизвестного kid, но не чаще раза в minKeyRefresh. После неудачной загрузки
+// следующая попытка откладывается с удвоением паузы, а запросы продолжают работать со старым набором.
+//
+// JWKS загружается вне мьютекса и одним запросом на все ждущие его горутины, поэтому медленный SSO
+// задерживает только токены с неизвестным kid, и то не дольше их собственного контекста.
+type KeySet struct {
+	load    func(ctx context.Context) ([]byte, error)
+	refresh time.Duration
+	group   singleflight.Group
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	attemptedAt time.Time
+	failures    int
+}
+
+// NewFileKeySet читает JWKS из локального файла, например для офлайн-тестов.
+func NewFileKeySet(ctx context.Context, path string, refresh time.Duration) (*KeySet, error) {
+	return newKeySet(ctx, refresh, func(context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	})
+}
+
+// NewURLKeySet загружает JWKS по адресу SSO (обычно jwks_uri из .well-known/openid-configuration).
+func NewURLKeySet(ctx context.Context, url string, refresh time.Duration) (*KeySet, error) {
+	client := &http.Client{Timeout: jwksTimeout}
+
+	return newKeySet(ctx, refresh, func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+		}
+
+		return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	})
+}
+
+func newKeySet(ctx context.Context, refresh time.Duration, load func(ctx context.Context) ([]byte, error)) (*KeySet, error) {
+	ks := &KeySet{load: load, refresh: refresh}
+
+	keys, err := ks.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ks.keys = keys
+	ks.attemptedAt = time.Now()
+
+	return ks, nil
+}
+
+// Key возвращает ключ с идентификатором kid. Пустой kid допустим, только если ключ в наборе один.
+// Известный ключ отдаётся сразу, даже если набор пора перечитать: перечитывание идёт в фоне.
+// Неизвестный kid ждёт перечитывания, если оно сейчас разрешено.
+func (ks *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	ks.mu.Lock()
+	key, ok := ks.lookup(kid)
+	since := time.Since(ks.attemptedAt)
+	backoff := ks.backoff()
+	ks.mu.Unlock()
+
+	if ok {
+		if (backoff == 0 && since >= ks.refresh) || (backoff > 0 && since >= backoff) {
+			ks.group.DoChan("reload", ks.reload)
+		}
+		return key, nil
+	}
+
+	if since < max(minKeyRefresh, backoff) {
+		return nil, errUnknownKey
+	}
+
+	select {
+	case result := <-ks.group.DoChan("reload", ks.reload):
+		if result.Err != nil {
+			return nil, result.Err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if key, ok = ks.lookup(kid); !ok {
+		return nil, errUnknownKey
+	}
+
+	return key, nil
+}
+
+// backoff — пауза до следующей попытки после неудачных загрузок; 0, если последняя загрузка удалась.
+func (ks *KeySet) backoff() time.Duration {
+	if ks.failures == 0 {
+		return 0
+	}
+
+	return min(minKeyRefresh<<min(ks.failures-1, 10), maxKeyBackoff)
+}
+
+func (ks *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+// reload загружает набор без мьютекса и подменяет им текущий. Время попытки запоминается
+// и при ошибке, иначе каждый следующий токен снова шёл бы в недоступный SSO.
+// Контекст не берётся из запроса: загрузку ждут сразу несколько запросов, и отмена одного
+// из них не должна обрывать её для остальных.
+func (ks *KeySet) reload() (any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), jwksTimeout)
+	defer cancel()
+
+	keys, err := ks.fetch(ctx)
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.attemptedAt = time.Now()
+	if err != nil {
+		ks.failures++
+		slog.Warn("JWKS refresh failed, keeping previous keys",
+			slog.Int("failures", ks.failures), slog.String("retry_in", ks.backoff().String()), logging.Error(err))
+		return nil, err
+	}
+
+	ks.keys = keys
+	ks.failures = 0
+
+	return nil, nil
+}
+
+func (ks *KeySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	data, err := ks.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load JWKS: %w", err)
+	}
+
+	return parseJWKS(data)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS разбирает ключи RSA и EC P-256. Ключи шифрования и других типов пропускаются.
+func parseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parse JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		var (
+			key crypto.PublicKey
+			err error
+		)
+		switch k.Kty {
+		case "RSA":
+			key, err = rsaKey(k)
+		case "EC":
+			if k.Crv != "P-256" {
+				continue
+			}
+			key, err = ecKey(k)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse JWKS key %q: %w", k.Kid, err)
+		}
+
+		keys[k.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("parse JWKS: no RSA or P-256 signing keys")
+	}
+
+	return keys, nil
+}
+
+func rsaKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode n: %w", err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode e: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid RSA key")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func ecKey(k jwk) (*ecdsa.PublicKey, error) {
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("decode x: %w", err)
+	}
+
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return nil, fmt.Errorf("decode y: %w", err)
+	}
+
+	if len(x) != 32 || len(y) != 32 {
+		return nil, errors.New("invalid P-256 coordinates")
+	}
+
+	// ecdh проверяет, что точка лежит на кривой
+	point := append(append([]byte{4}, x...), y...)
+	if _, err = ecdh.P256().NewPublicKey(point); err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}, nil
+}
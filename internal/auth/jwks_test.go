@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// jwksServer — локальный SSO: отдаёт текущий JWKS через delay или 503, пока failing == true, и считает запросы.
+type jwksServer struct {
+	*httptest.Server
+	requests atomic.Int32
+	failing  atomic.Bool
+	delay    atomic.Int64
+
+	mu   sync.Mutex
+	jwks []byte
+}
+
+func newJWKSServer(t *testing.T, jwks []byte) *jwksServer {
+	t.Helper()
+
+	s := &jwksServer{jwks: jwks}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		time.Sleep(time.Duration(s.delay.Load()))
+		if s.failing.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		_, _ = w.Write(s.jwks)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *jwksServer) setJWKS(jwks []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jwks = jwks
+}
+
+// rewind сдвигает время последней загрузки в прошлое, чтобы не ждать minKeyRefresh в тесте.
+func rewind(ks *KeySet, d time.Duration) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.attemptedAt = time.Now().Add(-d)
+}
+
+func TestKeySetBackoffAfterFailedRefresh(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSServer(t, jwksJSON(t, map[string]crypto.PublicKey{"rsa-1": &testRSAKey.PublicKey}))
+
+	ks, err := NewURLKeySet(ctx, server.URL, time.Hour)
+	if err != nil {
+		t.Fatalf("load key set: %v", err)
+	}
+	if got := server.requests.Load(); got != 1 {
+		t.Fatalf("initial requests = %d, want 1", got)
+	}
+
+	// неизвестный kid сразу после загрузки SSO не трогает
+	if _, err = ks.Key(ctx, "rsa-2"); !errors.Is(err, errUnknownKey) {
+		t.Fatalf("Key(rsa-2) error = %v, want errUnknownKey", err)
+	}
+	if got := server.requests.Load(); got != 1 {
+		t.Fatalf("requests = %d, want 1: unknown kid must not refresh within minKeyRefresh", got)
+	}
+
+	server.failing.Store(true)
+
+	steps := []struct {
+		name         string
+		rewind       time.Duration
+		wantRequests int32
+		wantBackoff  time.Duration
+	}{
+		// первая неудача: дальше пауза minKeyRefresh
+		{name: "refresh fails", rewind: minKeyRefresh, wantRequests: 2, wantBackoff: minKeyRefresh},
+		{name: "retry suppressed by backoff", rewind: minKeyRefresh / 2, wantRequests: 2, wantBackoff: minKeyRefresh},
+		// вторая неудача удваивает паузу
+		{name: "retry after backoff fails", rewind: minKeyRefresh, wantRequests: 3, wantBackoff: 2 * minKeyRefresh},
+		{name: "doubled backoff holds", rewind: minKeyRefresh + time.Second, wantRequests: 3, wantBackoff: 2 * minKeyRefresh},
+	}
+
+	for _, step := range steps {
+		rewind(ks, step.rewind)
+
+		if _, err = ks.Key(ctx, "rsa-2"); err == nil {
+			t.Fatalf("%s: Key(rsa-2) succeeded, want error", step.name)
+		}
+		if got := server.requests.Load(); got != step.wantRequests {
+			t.Fatalf("%s: requests = %d, want %d", step.name, got, step.wantRequests)
+		}
+
+		ks.mu.Lock()
+		backoff := ks.backoff()
+		ks.mu.Unlock()
+		if backoff != step.wantBackoff {
+			t.Fatalf("%s: backoff = %s, want %s", step.name, backoff, step.wantBackoff)
+		}
+
+		// пока SSO недоступен, известные ключи продолжают работать
+		if _, err = ks.Key(ctx, "rsa-1"); err != nil {
+			t.Fatalf("%s: Key(rsa-1) error = %v, want previous key", step.name, err)
+		}
+	}
+
+	// SSO вернулся с новым ключом: после паузы неизвестный kid подхватывается, счётчик неудач сбрасывается
+	server.failing.Store(false)
+	server.setJWKS(jwksJSON(t, map[string]crypto.PublicKey{
+		"rsa-1": &testRSAKey.PublicKey,
+		"rsa-2": &testRSAKey.PublicKey,
+	}))
+	rewind(ks, 2*minKeyRefresh)
+
+	if _, err = ks.Key(ctx, "rsa-2"); err != nil {
+		t.Fatalf("Key(rsa-2) after recovery error = %v", err)
+	}
+
+	ks.mu.Lock()
+	failures := ks.failures
+	ks.mu.Unlock()
+	if failures != 0 {
+		t.Errorf("failures = %d after successful refresh, want 0", failures)
+	}
+}
+
+func TestKeySetBackoffLimit(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 0, want: 0},
+		{failures: 1, want: minKeyRefresh},
+		{failures: 2, want: 2 * minKeyRefresh},
+		{failures: 4, want: 8 * minKeyRefresh},
+		{failures: 5, want: maxKeyBackoff},
+		{failures: 100, want: maxKeyBackoff},
+	}
+
+	for _, tt := range tests {
+		ks := &KeySet{failures: tt.failures}
+		if got := ks.backoff(); got != tt.want {
+			t.Errorf("backoff() with %d failures = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestKeySetRefreshesStaleSetInBackground(t *testing.T) {
+	ctx := context.Background()
+	server := newJWKSServer(t, jwksJSON(t, map[string]crypto.PublicKey{"ec-1": &testECKey.PublicKey}))
+
+	ks, err := NewURLKeySet(ctx, server.URL, time.Minute)
+	if err != nil {
+		t.Fatalf("load key set: %v", err)
+	}
+
+	// SSO отвечает медленно, но известный ключ из устаревшего набора отдаётся без ожидания
+	server.delay.Store(int64(500 * time.Millisecond))
+	rewind(ks, 2*time.Minute)
+
+	start := time.Now()
+	if _, err = ks.Key(ctx, "ec-1"); err != nil {
+		t.Fatalf("Key(ec-1) error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Key(ec-1) took %s, want immediate answer", elapsed)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for server.requests.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("stale key set was not refreshed in background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// clockSkew — допустимое расхождение часов с SSO при проверке exp, nbf и iat.
+const clockSkew = 30 * time.Second
+
+// JWTVerifier принимает JWT корпоративного SSO, подписанные RS256 или ES256 ключом из JWKS.
+type JWTVerifier struct {
+	keys         *KeySet
+	parser       *jwt.Parser
+	subjectClaim string
+	roleClaim    []string
+	roleMapping  map[string]models.Role
+}
+
+// NewJWTVerifier загружает JWKS из JWT_JWKS_FILE или JWT_JWKS_URL. Если ни один не задан, возвращает nil.
+func NewJWTVerifier(ctx context.Context, cfg *config.Config) (*JWTVerifier, error) {
+	var (
+		keys *KeySet
+		err  error
+	)
+	switch {
+	case cfg.JWTJWKSFile != "":
+		keys, err = NewFileKeySet(ctx, cfg.JWTJWKSFile, cfg.JWTJWKSRefresh)
+	case cfg.JWTJWKSURL != "":
+		keys, err = NewURLKeySet(ctx, cfg.JWTJWKSURL, cfg.JWTJWKSRefresh)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWT signing keys: %w", err)
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	}
+	if cfg.JWTIssuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.JWTIssuer))
+	}
+	if cfg.JWTAudience != "" {
+		options = append(options, jwt.WithAudience(cfg.JWTAudience))
+	}
+
+	roleMapping := make(map[string]models.Role, len(cfg.JWTRoleMapping))
+	for ssoRole, role := range cfg.JWTRoleMapping {
+		roleMapping[ssoRole] = models.Role(role)
+	}
+
+	return &JWTVerifier{
+		keys:         keys,
+		parser:       jwt.NewParser(options...),
+		subjectClaim: cfg.JWTSubjectClaim,
+		roleClaim:    strings.Split(cfg.JWTRoleClaim, "."),
+		roleMapping:  roleMapping,
+	}, nil
+}
+
+// Authenticate проверяет подпись и срок действия JWT. Строки, не похожие на JWT, и недействительные
+// токены дают ErrUnauthenticated, чтобы middleware могла попробовать статические токены;
+// действительный токен без роли сервиса — ErrForbidden.
+func (v *JWTVerifier) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	if strings.Count(token, ".") != 2 {
+		return nil, apperrors.ErrUnauthenticated
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, apperrors.ErrUnauthenticated
+	}
+
+	subject, _ := claims[v.subjectClaim].(string)
+	if subject == "" {
+		return nil, apperrors.ErrUnauthenticated
+	}
+
+	role, ok := v.role(claims)
+	if !ok {
+		return nil, apperrors.ErrForbidden
+	}
+
+	return &Identity{Subject: subject, Role: role, UserID: subject}, nil
+}
+
+// role сопоставляет роли SSO из claim ролям сервиса. Если подходит несколько, побеждает ADMIN.
+func (v *JWTVerifier) role(claims jwt.MapClaims) (models.Role, bool) {
+	var value any = map[string]any(claims)
+	for _, key := range v.roleClaim {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		value = object[key]
+	}
+
+	var ssoRoles []string
+	switch value := value.(type) {
+	case string:
+		// claim scope в OAuth2 — строка через пробел
+		ssoRoles = strings.Fields(value)
+	case []any:
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				ssoRoles = append(ssoRoles, s)
+			}
+		}
+	}
+
+	var role models.Role
+	for _, ssoRole := range ssoRoles {
+		switch v.roleMapping[ssoRole] {
+		case models.RoleAdmin:
+			return models.RoleAdmin, true
+		case models.RoleUser:
+			role = models.RoleUser
+		}
+	}
+
+	return role, role != ""
+}
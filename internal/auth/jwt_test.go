@@ -0,0 +1,323 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://sso.example.com"
+	testAudience = "review-service"
+)
+
+var (
+	testRSAKey = mustRSAKey()
+	testECKey  = mustECKey()
+)
+
+func mustRSAKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+func mustECKey() *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+// jwksJSON строит JWKS с открытыми ключами по kid.
+func jwksJSON(t *testing.T, keys map[string]crypto.PublicKey) []byte {
+	t.Helper()
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	coordinate := func(v *big.Int) string { return encode(v.FillBytes(make([]byte, 32))) }
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for kid, key := range keys {
+		switch key := key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, jwk{
+				Kty: "RSA", Kid: kid, Use: "sig",
+				N: encode(key.N.Bytes()), E: encode(big.NewInt(int64(key.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			set.Keys = append(set.Keys, jwk{
+				Kty: "EC", Kid: kid, Use: "sig", Crv: "P-256",
+				X: coordinate(key.X), Y: coordinate(key.Y),
+			})
+		default:
+			t.Fatalf("unsupported key type %T", key)
+		}
+	}
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("marshal JWKS: %v", err)
+	}
+
+	return data
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	return signed
+}
+
+// validClaims — claims, которые проверяющий принимает; тесты портят их по одному.
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+
+	return jwt.MapClaims{
+		"sub": "u1",
+		"iss": testIssuer,
+		"aud": testAudience,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+		"realm_access": map[string]any{
+			"roles": []any{"developer"},
+		},
+	}
+}
+
+func newTestVerifier(t *testing.T) *JWTVerifier {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	data := jwksJSON(t, map[string]crypto.PublicKey{
+		"rsa-1": &testRSAKey.PublicKey,
+		"ec-1":  &testECKey.PublicKey,
+	})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write JWKS: %v", err)
+	}
+
+	verifier, err := NewJWTVerifier(context.Background(), &config.Config{
+		JWTJWKSFile:     path,
+		JWTJWKSRefresh:  15 * time.Minute,
+		JWTIssuer:       testIssuer,
+		JWTAudience:     testAudience,
+		JWTSubjectClaim: "sub",
+		JWTRoleClaim:    "realm_access.roles",
+		JWTRoleMapping:  map[string]string{"developer": models.RoleUser, "platform-admin": models.RoleAdmin},
+	})
+	if err != nil {
+		t.Fatalf("create verifier: %v", err)
+	}
+
+	return verifier
+}
+
+func TestJWTVerifierSignature(t *testing.T) {
+	verifier := newTestVerifier(t)
+
+	rsaPublic, err := x509.MarshalPKIXPublicKey(&testRSAKey.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal RSA public key: %v", err)
+	}
+	otherRSAKey := mustRSAKey()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "RS256", token: signToken(t, jwt.SigningMethodRS256, testRSAKey, "rsa-1", validClaims())},
+		{name: "ES256", token: signToken(t, jwt.SigningMethodES256, testECKey, "ec-1", validClaims())},
+		{
+			name:    "alg none",
+			token:   signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "rsa-1", validClaims()),
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			// подмена алгоритма: HMAC с открытым ключом RSA в качестве секрета
+			name:    "HS256 with public key",
+			token:   signToken(t, jwt.SigningMethodHS256, rsaPublic, "rsa-1", validClaims()),
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "RS384",
+			token:   signToken(t, jwt.SigningMethodRS384, testRSAKey, "rsa-1", validClaims()),
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "unknown kid",
+			token:   signToken(t, jwt.SigningMethodRS256, testRSAKey, "rsa-2", validClaims()),
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "signed by another key",
+			token:   signToken(t, jwt.SigningMethodRS256, otherRSAKey, "rsa-1", validClaims()),
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "ES256 under RSA kid",
+			token:   signToken(t, jwt.SigningMethodES256, testECKey, "rsa-1", validClaims()),
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{name: "not a JWT", token: "static-api-token", wantErr: apperrors.ErrUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Authenticate(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (identity.Subject != "u1" || identity.UserID != "u1" || identity.Role != models.RoleUser) {
+				t.Errorf("identity = %+v, want u1 with role USER", identity)
+			}
+		})
+	}
+}
+
+func TestJWTVerifierClaims(t *testing.T) {
+	verifier := newTestVerifier(t)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		modify  func(claims jwt.MapClaims)
+		wantErr error
+	}{
+		{name: "valid", modify: func(jwt.MapClaims) {}},
+		{
+			name:    "expired",
+			modify:  func(c jwt.MapClaims) { c["exp"] = now.Add(-time.Hour).Unix() },
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:   "expired within clock skew",
+			modify: func(c jwt.MapClaims) { c["exp"] = now.Add(-10 * time.Second).Unix() },
+		},
+		{
+			name:    "no exp",
+			modify:  func(c jwt.MapClaims) { delete(c, "exp") },
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "not yet valid",
+			modify:  func(c jwt.MapClaims) { c["nbf"] = now.Add(time.Hour).Unix() },
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "issued in the future",
+			modify:  func(c jwt.MapClaims) { c["iat"] = now.Add(time.Hour).Unix() },
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "other issuer",
+			modify:  func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "no issuer",
+			modify:  func(c jwt.MapClaims) { delete(c, "iss") },
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:    "other audience",
+			modify:  func(c jwt.MapClaims) { c["aud"] = "billing" },
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+		{
+			name:   "audience list",
+			modify: func(c jwt.MapClaims) { c["aud"] = []any{"billing", testAudience} },
+		},
+		{
+			name:    "no subject",
+			modify:  func(c jwt.MapClaims) { delete(c, "sub") },
+			wantErr: apperrors.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.modify(claims)
+
+			_, err := verifier.Authenticate(context.Background(), signToken(t, jwt.SigningMethodRS256, testRSAKey, "rsa-1", claims))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTVerifierRoles(t *testing.T) {
+	verifier := newTestVerifier(t)
+
+	tests := []struct {
+		name     string
+		roles    any
+		want     models.Role
+		wantErr  error
+		noClaims bool
+	}{
+		{name: "mapped user role", roles: []any{"developer"}, want: models.RoleUser},
+		{name: "admin wins", roles: []any{"developer", "platform-admin"}, want: models.RoleAdmin},
+		{name: "unmapped roles ignored", roles: []any{"viewer", "developer"}, want: models.RoleUser},
+		{name: "space separated string", roles: "viewer platform-admin", want: models.RoleAdmin},
+		{name: "no mapped role", roles: []any{"viewer"}, wantErr: apperrors.ErrForbidden},
+		{name: "roles of wrong type", roles: 42, wantErr: apperrors.ErrForbidden},
+		{name: "no role claim", noClaims: true, wantErr: apperrors.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			if tt.noClaims {
+				delete(claims, "realm_access")
+			} else {
+				claims["realm_access"] = map[string]any{"roles": tt.roles}
+			}
+
+			identity, err := verifier.Authenticate(context.Background(), signToken(t, jwt.SigningMethodES256, testECKey, "ec-1", claims))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && identity.Role != tt.want {
+				t.Errorf("role = %s, want %s", identity.Role, tt.want)
+			}
+		})
+	}
+}
@@ -1,16 +1,89 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"text/template"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+	StorageSQLite   = "sqlite"
+)
+
+const (
+	TracingNone = "none"
+	TracingOTLP = "otlp"
+	TracingFile = "file"
+)
+
+// publishedAdminToken — пример ADMIN_TOKEN из старых версий .env в репозитории, известный всем.
+const publishedAdminToken = "dont_forget_admin_token"
+
 type Config struct {
+	// LogLevel — минимальный уровень JSON-логов: debug, info, warn или error.
+	LogLevel slog.Level `env:"LOG_LEVEL" env-default:"info"`
+	// Storage выбирает хранилище: postgres, sqlite или memory (данные живут только до перезапуска).
+	Storage string `env:"STORAGE" env-default:"postgres"`
+	// SQLitePath — путь к файлу базы для STORAGE=sqlite.
+	SQLitePath string `env:"SQLITE_PATH" env-default:"review.db"`
+	// MigrateOnStart применяет встроенные миграции перед запуском сервера.
+	MigrateOnStart bool `env:"MIGRATE_ON_START" env-default:"true"`
+	// GitHubWebhookSecret — секрет для проверки X-Hub-Signature-256; пока он не задан, /webhooks/github отклоняет все запросы.
+	GitHubWebhookSecret string `env:"GITHUB_WEBHOOK_SECRET"`
+	// GitLabWebhookToken — секретный токен, который GitLab передаёт в X-Gitlab-Token; пока он не задан, /webhooks/gitlab отклоняет все запросы.
+	GitLabWebhookToken string `env:"GITLAB_WEBHOOK_TOKEN"`
+	// WebhookPollInterval — как часто диспетчер исходящих вебхуков проверяет outbox и очередь повторов.
+	WebhookPollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" env-default:"1s"`
+	// WebhookMaxAttempts — после стольких неудачных попыток доставка помечается FAILED.
+	WebhookMaxAttempts int `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"10"`
+	// WebhookRetryBackoff — задержка перед первым повтором, дальше она удваивается (но не больше часа).
+	WebhookRetryBackoff time.Duration `env:"WEBHOOK_RETRY_BACKOFF" env-default:"5s"`
+	// ChatMessageTemplate — text/template для уведомлений в чат команды; пустой — встроенный шаблон.
+	ChatMessageTemplate string `env:"CHAT_MESSAGE_TEMPLATE"`
+	// AuthEnabled включает проверку bearer-токенов; выключать стоит только для локальной разработки.
+	AuthEnabled bool `env:"AUTH_ENABLED" env-default:"true"`
+	// AdminToken — токен администратора из окружения, нужен, чтобы выпустить первые токены через /tokens/issue.
+	AdminToken string `env:"ADMIN_TOKEN"`
+	// JWTJWKSFile — локальный JWKS с ключами SSO; удобен для офлайн-тестов. Задаётся либо он, либо JWT_JWKS_URL.
+	JWTJWKSFile string `env:"JWT_JWKS_FILE"`
+	// JWTJWKSURL — адрес JWKS корпоративного SSO; пока не задан ни он, ни JWT_JWKS_FILE, JWT не принимаются.
+	JWTJWKSURL string `env:"JWT_JWKS_URL"`
+	// JWTJWKSRefresh — как часто перечитывать JWKS, чтобы подхватить ротацию ключей.
+	JWTJWKSRefresh time.Duration `env:"JWT_JWKS_REFRESH" env-default:"15m"`
+	// JWTIssuer — ожидаемый iss; пустой — не проверяется.
+	JWTIssuer string `env:"JWT_ISSUER"`
+	// JWTAudience — ожидаемый aud; пустой — не проверяется.
+	JWTAudience string `env:"JWT_AUDIENCE"`
+	// JWTSubjectClaim — claim с user_id вызывающего.
+	JWTSubjectClaim string `env:"JWT_SUBJECT_CLAIM" env-default:"sub"`
+	// JWTRoleClaim — claim со списком ролей SSO; вложенные claims указываются через точку, например realm_access.roles.
+	JWTRoleClaim string `env:"JWT_ROLE_CLAIM" env-default:"roles"`
+	// JWTRoleMapping сопоставляет роли SSO ролям сервиса: "sso-роль:ADMIN,другая:USER".
+	JWTRoleMapping map[string]string `env:"JWT_ROLE_MAPPING" env-default:"admin:ADMIN,user:USER"`
+	// TracingExporter — куда отправлять трассировки OpenTelemetry: none, otlp (OTLP/HTTP) или file (JSON в TRACING_FILE).
+	TracingExporter string `env:"TRACING_EXPORTER" env-default:"none"`
+	// TracingOTLPEndpoint — адрес OTLP/HTTP коллектора; http:// отключает TLS.
+	TracingOTLPEndpoint string `env:"TRACING_OTLP_ENDPOINT" env-default:"http://localhost:4318"`
+	// TracingFile — файл для TRACING_EXPORTER=file, спаны дописываются в конец.
+	TracingFile string `env:"TRACING_FILE" env-default:"traces.json"`
+	// TracingSampleRatio — доля трассировок, которые начинает сам сервис; решение из входящего traceparent соблюдается.
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+	// ShutdownDrainDelay — сколько после сигнала остановки /health/ready отвечает 503, прежде чем сервер
+	// перестанет принимать соединения; должно покрывать интервал проб балансировщика.
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" env-default:"5s"`
+
 	Host     string `env:"DB_HOST" env-default:"db"`
 	Port     string `env:"DB_PORT" env-default:"5432"`
 	User     string `env:"DB_USER" env-default:"postgres"`
-	Password string `env:"DB_PASSWORD" env-required:"true"`
+	Password string `env:"DB_PASSWORD"`
 	Name     string `env:"DB_NAME" env-default:"postgres"`
 	SSL      string `env:"DB_SSL" env-default:"disable"`
 	Pool     int32  `env:"DB_POOL" env-default:"10"`
@@ -22,5 +95,59 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
+	switch cfg.Storage {
+	case StoragePostgres:
+		if cfg.Password == "" {
+			return nil, errors.New("failed to load config: DB_PASSWORD is required for postgres storage")
+		}
+	case StorageMemory, StorageSQLite:
+	default:
+		return nil, fmt.Errorf("failed to load config: unknown STORAGE %q", cfg.Storage)
+	}
+
+	if cfg.WebhookPollInterval <= 0 {
+		return nil, errors.New("failed to load config: WEBHOOK_POLL_INTERVAL must be positive")
+	}
+
+	if cfg.WebhookRetryBackoff <= 0 {
+		return nil, errors.New("failed to load config: WEBHOOK_RETRY_BACKOFF must be positive")
+	}
+
+	if cfg.AdminToken == publishedAdminToken {
+		return nil, errors.New("failed to load config: ADMIN_TOKEN still holds the example value from the repository, generate your own")
+	}
+
+	if _, err := template.New("chat").Parse(cfg.ChatMessageTemplate); err != nil {
+		return nil, fmt.Errorf("failed to load config: invalid CHAT_MESSAGE_TEMPLATE: %w", err)
+	}
+
+	if cfg.JWTJWKSFile != "" && cfg.JWTJWKSURL != "" {
+		return nil, errors.New("failed to load config: set only one of JWT_JWKS_FILE and JWT_JWKS_URL")
+	}
+
+	if cfg.JWTJWKSRefresh <= 0 {
+		return nil, errors.New("failed to load config: JWT_JWKS_REFRESH must be positive")
+	}
+
+	for ssoRole, role := range cfg.JWTRoleMapping {
+		if models.Role(role) != models.RoleAdmin && models.Role(role) != models.RoleUser {
+			return nil, fmt.Errorf("failed to load config: JWT_ROLE_MAPPING maps %q to unknown role %q", ssoRole, role)
+		}
+	}
+
+	switch cfg.TracingExporter {
+	case TracingNone, TracingOTLP, TracingFile:
+	default:
+		return nil, fmt.Errorf("failed to load config: unknown TRACING_EXPORTER %q", cfg.TracingExporter)
+	}
+
+	if cfg.TracingSampleRatio < 0 || cfg.TracingSampleRatio > 1 {
+		return nil, errors.New("failed to load config: TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+
+	if cfg.ShutdownDrainDelay < 0 {
+		return nil, errors.New("failed to load config: SHUTDOWN_DRAIN_DELAY must not be negative")
+	}
+
 	return cfg, nil
 }
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -35,8 +36,17 @@ func (db *Database) Pool() *pgxpool.Pool {
 	return db.pool
 }
 
-func (db *Database) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
-	return db.pool.BeginTx(ctx, txOptions)
+func (db *Database) Conn() Conn {
+	return PostgresConn{db.pool}
+}
+
+func (db *Database) BeginTx(ctx context.Context) (Tx, error) {
+	tx, err := db.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return nil, err
+	}
+
+	return PostgresTx{tx}, nil
 }
 
 func (db *Database) HealthCheck(ctx context.Context) error {
@@ -46,6 +56,33 @@ func (db *Database) HealthCheck(ctx context.Context) error {
 	return db.pool.Ping(ctx)
 }
 
+func (db *Database) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := db.pool.QueryRow(ctx, schemaVersionQuery).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return uint(version), dirty, nil
+}
+
+func (db *Database) PoolStats() PoolStats {
+	stat := db.pool.Stat()
+
+	return PoolStats{
+		InUse:     int(stat.AcquiredConns()),
+		Idle:      int(stat.IdleConns()),
+		Max:       int(stat.MaxConns()),
+		WaitCount: stat.EmptyAcquireCount(),
+	}
+}
+
 func (db *Database) Close() {
 	if db.pool != nil {
 		db.pool.Close()
@@ -63,3 +100,21 @@ func connectionString(dbConfig *config.Config) string {
 		dbConfig.SSL,
 	)
 }
+
+// PostgresConn и PostgresTx помечают пул и транзакцию pgx как соединения Postgres;
+// методы запросов остаются доступны репозиториям postgres.
+type PostgresConn struct {
+	*pgxpool.Pool
+}
+
+func (PostgresConn) StorageName() string {
+	return config.StoragePostgres
+}
+
+type PostgresTx struct {
+	pgx.Tx
+}
+
+func (PostgresTx) StorageName() string {
+	return config.StoragePostgres
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/migrations"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// ErrNoMigrations возвращается для хранилищ без схемы (memory).
+var ErrNoMigrations = errors.New("storage has no migrations")
+
+// Migrator применяет встроенные миграции к хранилищу из конфигурации.
+//
+// Версия схемы хранится в той же таблице schema_migrations, что ведёт CLI golang-migrate,
+// поэтому базы, размеченные контейнером migrate, продолжают мигрировать с того же места.
+// Для Postgres на время работы берётся pg_advisory_lock: несколько реплик, стартующих
+// одновременно, применяют миграции по очереди, а не наперегонки.
+type Migrator struct {
+	m      *migrate.Migrate
+	source source.Driver
+}
+
+// MigrationStatus — текущая версия схемы и ещё не применённые миграции.
+type MigrationStatus struct {
+	Version uint
+	Dirty   bool
+	Pending []uint
+}
+
+func NewMigrator(cfg *config.Config) (*Migrator, error) {
+	dir, driver, err := openMigrationTarget(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := iofs.New(migrations.FS, dir)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("read embedded migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, cfg.Storage, driver)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("init migrations: %w", err)
+	}
+	m.Log = migrateLogger{}
+
+	return &Migrator{m: m, source: src}, nil
+}
+
+// openMigrationTarget открывает отдельное соединение для миграций. Строка подключения
+// не передаётся в golang-migrate, чтобы пароль не попал в текст его ошибок.
+func openMigrationTarget(cfg *config.Config) (string, migratedb.Driver, error) {
+	var (
+		dir, driverName, dsn string
+		withInstance         func(*sql.DB) (migratedb.Driver, error)
+	)
+	switch cfg.Storage {
+	case config.StoragePostgres:
+		dir, driverName, dsn = migrations.PostgresDir, "pgx", connectionString(cfg)
+		withInstance = func(db *sql.DB) (migratedb.Driver, error) {
+			return migratepgx.WithInstance(db, &migratepgx.Config{})
+		}
+	case config.StorageSQLite:
+		dir, driverName, dsn = migrations.SQLiteDir, "sqlite", cfg.SQLitePath
+		withInstance = func(db *sql.DB) (migratedb.Driver, error) {
+			return migratesqlite.WithInstance(db, &migratesqlite.Config{})
+		}
+	default:
+		return "", nil, ErrNoMigrations
+	}
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return "", nil, fmt.Errorf("open database for migrations: %w", err)
+	}
+
+	driver, err := withInstance(db)
+	if err != nil {
+		db.Close()
+		return "", nil, fmt.Errorf("open database for migrations: %w", err)
+	}
+
+	return dir, driver, nil
+}
+
+// Up применяет все ещё не применённые миграции. Актуальная схема ошибкой не считается.
+func (mg *Migrator) Up() error {
+	if err := mg.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+
+	return nil
+}
+
+// Down откатывает steps последних миграций.
+func (mg *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("rollback migrations: steps must be positive, got %d", steps)
+	}
+
+	if err := mg.m.Steps(-steps); err != nil {
+		return fmt.Errorf("rollback migrations: %w", err)
+	}
+
+	return nil
+}
+
+func (mg *Migrator) Status() (*MigrationStatus, error) {
+	version, dirty, err := mg.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+	status := &MigrationStatus{Version: version, Dirty: dirty}
+
+	next, err := mg.source.First()
+	for err == nil {
+		if next > version {
+			status.Pending = append(status.Pending, next)
+		}
+		next, err = mg.source.Next(next)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+
+	return status, nil
+}
+
+// LatestMigration возвращает версию последней встроенной миграции для хранилища из конфигурации.
+func LatestMigration(storage string) (uint, error) {
+	var dir string
+	switch storage {
+	case config.StoragePostgres:
+		dir = migrations.PostgresDir
+	case config.StorageSQLite:
+		dir = migrations.SQLiteDir
+	default:
+		return 0, ErrNoMigrations
+	}
+
+	src, err := iofs.New(migrations.FS, dir)
+	if err != nil {
+		return 0, fmt.Errorf("read embedded migrations: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	for err == nil {
+		var next uint
+		if next, err = src.Next(version); err == nil {
+			version = next
+		}
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("list migrations: %w", err)
+	}
+
+	return version, nil
+}
+
+func (mg *Migrator) Close() error {
+	srcErr, dbErr := mg.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+type migrateLogger struct{}
+
+func (migrateLogger) Printf(format string, v ...interface{}) {
+	slog.Info("migrate: " + strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (migrateLogger) Verbose() bool {
+	return false
+}
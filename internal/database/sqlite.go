@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLite — хранилище поверх файла SQLite.
+//
+// Транзакции открываются как BEGIN IMMEDIATE: пишущая транзакция сразу берёт блокировку
+// на запись, поэтому SQLite выполняет их по одной и не возникает ситуаций, которые
+// в Postgres закончились бы ошибкой сериализации. Конкурирующие транзакции ждут busy_timeout.
+type SQLite struct {
+	db *sql.DB
+}
+
+func NewSQLite(cfg *config.Config) (*SQLite, error) {
+	db, err := sql.Open("sqlite", sqliteDSN(cfg.SQLitePath))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+
+	db.SetMaxOpenConns(int(cfg.Pool))
+
+	return &SQLite{db: db}, nil
+}
+
+func (s *SQLite) DB() *sql.DB {
+	return s.db
+}
+
+func (s *SQLite) Conn() Conn {
+	return SQLiteConn{s.db}
+}
+
+func (s *SQLite) BeginTx(ctx context.Context) (Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLiteTx{Tx: tx}, nil
+}
+
+func (s *SQLite) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.db.PingContext(ctx)
+}
+
+func (s *SQLite) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := s.db.QueryRowContext(ctx, schemaVersionQuery).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return uint(version), dirty, nil
+}
+
+func (s *SQLite) PoolStats() PoolStats {
+	stats := s.db.Stats()
+
+	return PoolStats{
+		InUse:     stats.InUse,
+		Idle:      stats.Idle,
+		Max:       stats.MaxOpenConnections,
+		WaitCount: stats.WaitCount,
+	}
+}
+
+func (s *SQLite) Close() {
+	if s.db != nil {
+		s.db.Close()
+	}
+}
+
+// SQLiteConn помечает *sql.DB как соединение SQLite; методы запросов остаются доступны репозиториям sqlite.
+type SQLiteConn struct {
+	*sql.DB
+}
+
+func (SQLiteConn) StorageName() string {
+	return config.StorageSQLite
+}
+
+// SQLiteTx приводит *sql.Tx к интерфейсу Tx; методы запросов *sql.Tx остаются доступны репозиториям.
+type SQLiteTx struct {
+	*sql.Tx
+}
+
+func (*SQLiteTx) StorageName() string {
+	return config.StorageSQLite
+}
+
+func (tx *SQLiteTx) Commit(context.Context) error {
+	return tx.Tx.Commit()
+}
+
+func (tx *SQLiteTx) Rollback(context.Context) error {
+	err := tx.Tx.Rollback()
+	if err == sql.ErrTxDone {
+		return nil
+	}
+
+	return err
+}
+
+// sqliteDSN включает внешние ключи (в SQLite они по умолчанию выключены), WAL и ожидание блокировки,
+// а время пишет в формате, который корректно сравнивается как строка и понимается функциями даты SQLite.
+func sqliteDSN(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Set("_time_format", "sqlite")
+	params.Set("_txlock", "immediate")
+
+	return "file:" + path + "?" + params.Encode()
+}
@@ -0,0 +1,44 @@
+package database
+
+import "context"
+
+// schemaVersionQuery читает версию схемы из таблицы, которую ведёт golang-migrate.
+const schemaVersionQuery = "SELECT version, dirty FROM schema_migrations LIMIT 1"
+
+// Storage — хранилище, с которым работают сервисы. Conn и BeginTx возвращают соединение,
+// которое передаётся в репозитории этого же хранилища как repository.DBTX.
+type Storage interface {
+	// Conn возвращает соединение для запросов вне транзакции.
+	Conn() Conn
+	// BeginTx открывает транзакцию с уровнем изоляции serializable. Сервисы вызывают его через RunInTx,
+	// который повторяет транзакции после конфликтов сериализации.
+	BeginTx(ctx context.Context) (Tx, error)
+	HealthCheck(ctx context.Context) error
+	// SchemaVersion возвращает применённую версию миграций; для хранилищ без схемы — ErrNoMigrations.
+	SchemaVersion(ctx context.Context) (version uint, dirty bool, err error)
+	// PoolStats возвращает заполненность пула соединений; у хранилищ без пула Max == 0.
+	PoolStats() PoolStats
+	Close()
+}
+
+// PoolStats — соединения, занятые запросами, свободные, предел пула и сколько раз запросу
+// пришлось ждать соединения с момента запуска.
+type PoolStats struct {
+	InUse     int
+	Idle      int
+	Max       int
+	WaitCount int64
+}
+
+// Conn — соединение или транзакция хранилища. StorageName называет хранилище (config.Storage*):
+// репозиторий, получивший соединение чужого хранилища, возвращает ошибку вместо паники.
+type Conn interface {
+	StorageName() string
+}
+
+// Tx — открытая транзакция. Rollback после Commit ничего не делает, поэтому его можно откладывать через defer.
+type Tx interface {
+	Conn
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
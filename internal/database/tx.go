@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+	"github.com/AntonTsoy/review-pull-request-service/internal/metrics"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// maxTxAttempts — сколько раз транзакция выполняется, прежде чем ошибка уйдёт клиенту.
+	maxTxAttempts = 5
+	// txRetryBaseDelay удваивается с каждой попыткой до txRetryMaxDelay; на паузу накладывается случайный разброс,
+	// чтобы столкнувшиеся транзакции не повторялись снова одновременно.
+	txRetryBaseDelay = 20 * time.Millisecond
+	txRetryMaxDelay  = 500 * time.Millisecond
+)
+
+// RunInTx выполняет fn в транзакции и коммитит её, если fn не вернула ошибку. Транзакция, которую Postgres
+// откатил из-за конфликта сериализации (40001) или дедлока (40P01), повторяется целиком, до maxTxAttempts раз.
+// Поэтому fn может выполниться несколько раз: всё, что нельзя повторять (уведомления, метрики),
+// делается после RunInTx, а результаты fn присваивает заново на каждой попытке.
+func RunInTx(ctx context.Context, db Storage, fn func(tx Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		err := runTx(ctx, db, fn)
+
+		reason := retryReason(err)
+		if reason == "" {
+			return err
+		}
+		if attempt == maxTxAttempts {
+			return fmt.Errorf("transaction failed after %d attempts: %w", attempt, err)
+		}
+
+		delay := txRetryDelay(attempt)
+		metrics.TxRetried(reason)
+		slog.WarnContext(ctx, "retrying transaction",
+			slog.String("reason", reason),
+			slog.Int("attempt", attempt),
+			slog.String("delay", delay.String()),
+			logging.Error(err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func runTx(ctx context.Context, db Storage, fn func(tx Tx) error) error {
+	tx, err := db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// retryReason возвращает метку для метрики tx_retries, если транзакцию имеет смысл повторить.
+// SQLite открывает транзакции как BEGIN IMMEDIATE и таких ошибок не даёт, memory — тоже.
+func retryReason(err error) string {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return ""
+	}
+
+	switch pgErr.Code {
+	case pgerrcode.SerializationFailure:
+		return metrics.TxRetrySerializationFailure
+	case pgerrcode.DeadlockDetected:
+		return metrics.TxRetryDeadlock
+	}
+
+	return ""
+}
+
+// txRetryDelay — экспоненциальная пауза перед attempt+1-й попыткой, случайная в пределах [d/2, d).
+func txRetryDelay(attempt int) time.Duration {
+	delay := min(txRetryBaseDelay<<(attempt-1), txRetryMaxDelay)
+
+	return delay/2 + rand.N(delay/2)
+}
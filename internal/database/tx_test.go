@@ -0,0 +1,217 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/metrics"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeStorage считает начатые и закоммиченные транзакции; сами запросы тестам RunInTx не нужны.
+type fakeStorage struct {
+	begun     int
+	committed int
+}
+
+func (s *fakeStorage) Conn() Conn                                        { return fakeTx{} }
+func (s *fakeStorage) HealthCheck(context.Context) error                 { return nil }
+func (s *fakeStorage) PoolStats() PoolStats                              { return PoolStats{} }
+func (s *fakeStorage) Close()                                            {}
+func (s *fakeStorage) SchemaVersion(context.Context) (uint, bool, error) { return 0, false, nil }
+
+func (s *fakeStorage) BeginTx(context.Context) (Tx, error) {
+	s.begun++
+
+	return fakeTx{storage: s}, nil
+}
+
+type fakeTx struct {
+	storage *fakeStorage
+}
+
+func (fakeTx) StorageName() string { return "fake" }
+
+func (tx fakeTx) Commit(context.Context) error {
+	tx.storage.committed++
+
+	return nil
+}
+
+func (fakeTx) Rollback(context.Context) error { return nil }
+
+func pgError(code string) error {
+	return &pgconn.PgError{Code: code, Message: "test"}
+}
+
+func TestRetryReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "serialization failure", err: pgError(pgerrcode.SerializationFailure), want: metrics.TxRetrySerializationFailure},
+		{name: "deadlock", err: pgError(pgerrcode.DeadlockDetected), want: metrics.TxRetryDeadlock},
+		{
+			name: "wrapped serialization failure",
+			err:  fmt.Errorf("failed to commit transaction: %w", pgError(pgerrcode.SerializationFailure)),
+			want: metrics.TxRetrySerializationFailure,
+		},
+		{
+			name: "wrapped deadlock",
+			err:  fmt.Errorf("update user: %w", fmt.Errorf("exec: %w", pgError(pgerrcode.DeadlockDetected))),
+			want: metrics.TxRetryDeadlock,
+		},
+		{name: "unique violation", err: pgError(pgerrcode.UniqueViolation)},
+		{name: "lock not available", err: pgError(pgerrcode.LockNotAvailable)},
+		{name: "not a postgres error", err: errors.New("serialization failure")},
+		{name: "nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryReason(tt.err); got != tt.want {
+				t.Errorf("retryReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunInTx(t *testing.T) {
+	errBusiness := errors.New("business error")
+
+	tests := []struct {
+		name          string
+		failures      int
+		failErr       error
+		wantErr       error
+		wantAttempts  int
+		wantCommitted int
+	}{
+		{name: "success", wantAttempts: 1, wantCommitted: 1},
+		{
+			name:          "retried serialization failure",
+			failures:      2,
+			failErr:       pgError(pgerrcode.SerializationFailure),
+			wantAttempts:  3,
+			wantCommitted: 1,
+		},
+		{
+			name:          "retried wrapped deadlock",
+			failures:      1,
+			failErr:       fmt.Errorf("assign reviewer: %w", pgError(pgerrcode.DeadlockDetected)),
+			wantAttempts:  2,
+			wantCommitted: 1,
+		},
+		{
+			name:         "non-retryable postgres error",
+			failures:     maxTxAttempts,
+			failErr:      pgError(pgerrcode.UniqueViolation),
+			wantErr:      pgError(pgerrcode.UniqueViolation),
+			wantAttempts: 1,
+		},
+		{
+			name:         "business error",
+			failures:     maxTxAttempts,
+			failErr:      errBusiness,
+			wantErr:      errBusiness,
+			wantAttempts: 1,
+		},
+		{
+			name:         "gives up after maxTxAttempts",
+			failures:     maxTxAttempts + 1,
+			failErr:      pgError(pgerrcode.SerializationFailure),
+			wantErr:      pgError(pgerrcode.SerializationFailure),
+			wantAttempts: maxTxAttempts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeStorage{}
+			attempts := 0
+
+			err := RunInTx(context.Background(), db, func(Tx) error {
+				attempts++
+				if attempts <= tt.failures {
+					return tt.failErr
+				}
+				return nil
+			})
+
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("RunInTx() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !sameError(err, tt.wantErr) {
+				t.Fatalf("RunInTx() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts || db.begun != tt.wantAttempts {
+				t.Errorf("attempts = %d, transactions = %d, want %d", attempts, db.begun, tt.wantAttempts)
+			}
+			if db.committed != tt.wantCommitted {
+				t.Errorf("committed = %d, want %d", db.committed, tt.wantCommitted)
+			}
+		})
+	}
+}
+
+// sameError сравнивает ошибки по цепочке: для *pgconn.PgError — по коду, для остальных — через errors.Is.
+func sameError(err, want error) bool {
+	var wantPg *pgconn.PgError
+	if !errors.As(want, &wantPg) {
+		return errors.Is(err, want)
+	}
+
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == wantPg.Code
+}
+
+func TestRunInTxGivesUpWithAttemptCount(t *testing.T) {
+	err := RunInTx(context.Background(), &fakeStorage{}, func(Tx) error {
+		return pgError(pgerrcode.DeadlockDetected)
+	})
+
+	want := fmt.Sprintf("transaction failed after %d attempts", maxTxAttempts)
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("RunInTx() error = %v, want prefix %q", err, want)
+	}
+}
+
+func TestRunInTxStopsOnCancelDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db := &fakeStorage{}
+	attempts := 0
+
+	err := RunInTx(ctx, db, func(Tx) error {
+		attempts++
+		// клиент ушёл: пауза перед повтором прерывается, вторая попытка не начинается
+		cancel()
+		return pgError(pgerrcode.SerializationFailure)
+	})
+
+	if !sameError(err, pgError(pgerrcode.SerializationFailure)) {
+		t.Fatalf("RunInTx() error = %v, want serialization failure", err)
+	}
+	if attempts != 1 || db.begun != 1 {
+		t.Errorf("attempts = %d, transactions = %d, want 1: cancelled context must stop retries", attempts, db.begun)
+	}
+}
+
+func TestTxRetryDelay(t *testing.T) {
+	for attempt := 1; attempt < maxTxAttempts+3; attempt++ {
+		limit := min(txRetryBaseDelay<<(attempt-1), txRetryMaxDelay)
+
+		for range 100 {
+			if d := txRetryDelay(attempt); d < limit/2 || d >= limit {
+				t.Fatalf("txRetryDelay(%d) = %s, want within [%s, %s)", attempt, d, limit/2, limit)
+			}
+		}
+	}
+}
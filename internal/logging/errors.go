@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Error — атрибуты ошибки: текст и типы всех обёрток до первопричины,
+// например [*fmt.wrapError *pgconn.PgError].
+func Error(err error) slog.Attr {
+	return slog.Group("error",
+		slog.String("message", err.Error()),
+		slog.Any("chain", errorChain(err)),
+	)
+}
+
+func errorChain(err error) []string {
+	var chain []string
+	for err != nil {
+		chain = append(chain, fmt.Sprintf("%T", err))
+
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				chain = append(chain, errorChain(e)...)
+			}
+			break
+		}
+		err = errors.Unwrap(err)
+	}
+
+	return chain
+}
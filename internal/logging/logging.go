@@ -0,0 +1,80 @@
+// Package logging настраивает JSON-логи через log/slog и атрибуты, общие для всех строк одного запроса.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// ContextKey — ключ набора атрибутов запроса в контексте. Middleware кладёт набор через c.Locals,
+// поэтому он доступен из любого контекста, производного от контекста запроса.
+type ContextKey struct{}
+
+// Attrs — атрибуты, которые добавляются ко всем строкам лога в рамках запроса. Набор пополняется
+// по ходу обработки (например, трассировкой), поэтому он изменяемый и защищён мьютексом.
+type Attrs struct {
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+func (a *Attrs) Add(attrs ...slog.Attr) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.attrs = append(a.attrs, attrs...)
+}
+
+func (a *Attrs) list() []slog.Attr {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]slog.Attr(nil), a.attrs...)
+}
+
+// Add дописывает атрибуты к набору запроса; вне запроса ничего не делает.
+func Add(ctx context.Context, attrs ...slog.Attr) {
+	if a, ok := ctx.Value(ContextKey{}).(*Attrs); ok {
+		a.Add(attrs...)
+	}
+}
+
+// Detach переносит атрибуты запроса в новый контекст от context.Background. Нужен фоновым задачам,
+// которые переживают запрос: контекст запроса ссылается на fasthttp.RequestCtx, а fasthttp
+// переиспользует его для следующих запросов сразу после ответа.
+func Detach(ctx context.Context) context.Context {
+	detached := context.Background()
+	if a, ok := ctx.Value(ContextKey{}).(*Attrs); ok {
+		detached = context.WithValue(detached, ContextKey{}, &Attrs{attrs: a.list()})
+	}
+
+	return detached
+}
+
+// Setup делает JSON в stdout логгером по умолчанию; туда же попадает и вывод пакета log.
+func Setup(level slog.Level) {
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})
+	slog.SetDefault(slog.New(contextHandler{handler}))
+}
+
+// contextHandler добавляет к записи атрибуты запроса из контекста.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if a, ok := ctx.Value(ContextKey{}).(*Attrs); ok {
+		record.AddAttrs(a.list()...)
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,100 @@
+// Package metrics описывает метрики сервиса в формате Prometheus.
+package metrics
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "review_service"
+
+// Причины повтора транзакции — значения метки reason у tx_retries_total.
+const (
+	TxRetrySerializationFailure = "serialization_failure"
+	TxRetryDeadlock             = "deadlock"
+)
+
+// httpBuckets включают 0.3 с, чтобы долю запросов в пределах SLI времени ответа
+// можно было посчитать по бакету без интерполяции.
+var httpBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.2, 0.3, 0.5, 1, 2.5, 5}
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route and response status.",
+		Buckets:   httpBuckets,
+	}, []string{"method", "route", "status"})
+
+	pullRequestsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_created_total",
+		Help:      "Pull requests created, by source (api, github, gitlab).",
+	}, []string{"source"})
+
+	pullRequestsMerged = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pull_requests_merged_total",
+		Help:      "Pull requests moved to MERGED.",
+	})
+
+	reviewerReassignments = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviewer_reassignments_total",
+		Help:      "Pull requests whose reviewers were reassigned, by trigger (manual, deactivation).",
+	}, []string{"trigger"})
+
+	noCandidate = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "no_candidate_total",
+		Help:      "Operations short of reviewer candidates, by operation: create (PR created with missing_reviewers), reassign (rejected with NO_CANDIDATE).",
+	}, []string{"operation"})
+
+	txRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tx_retries_total",
+		Help:      "Transaction retries after a serialization failure or deadlock.",
+	}, []string{"reason"})
+)
+
+// ObserveHTTPRequest записывает длительность обработки запроса.
+func ObserveHTTPRequest(method, route string, status int, seconds float64) {
+	httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(seconds)
+}
+
+// PullRequestCreated учитывает новый PR. source == nil — PR создан через API.
+func PullRequestCreated(source *string) {
+	label := "api"
+	if source != nil {
+		label = strings.ToLower(*source)
+	}
+	pullRequestsCreated.WithLabelValues(label).Inc()
+}
+
+func PullRequestMerged() {
+	pullRequestsMerged.Inc()
+}
+
+// ReviewersReassigned учитывает prs PR, у которых заменили ревьюверов.
+func ReviewersReassigned(trigger string, prs int) {
+	reviewerReassignments.WithLabelValues(trigger).Add(float64(prs))
+}
+
+func NoCandidate(operation string) {
+	noCandidate.WithLabelValues(operation).Inc()
+}
+
+// серии tx_retries_total создаются сразу, чтобы до первого конфликта счётчик отдавался нулём, а не отсутствовал
+func init() {
+	for _, reason := range []string{TxRetrySerializationFailure, TxRetryDeadlock} {
+		txRetries.WithLabelValues(reason)
+	}
+}
+
+// TxRetried учитывает повтор транзакции; reason — TxRetrySerializationFailure или TxRetryDeadlock.
+func TxRetried(reason string) {
+	txRetries.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Absence struct {
+	ID       int
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   *string
+}
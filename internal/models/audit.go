@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type AuditAction = string
+
+const (
+	AuditTeamCreated         AuditAction = "team.created"
+	AuditUserActivityChanged AuditAction = "user.is_active_changed"
+	AuditPullRequestCreated  AuditAction = "pr.created"
+	AuditPullRequestMerged   AuditAction = "pr.merged"
+	AuditReviewerReassigned  AuditAction = "reviewer.reassigned"
+)
+
+type AuditEntity = string
+
+const (
+	AuditEntityTeam        AuditEntity = "TEAM"
+	AuditEntityUser        AuditEntity = "USER"
+	AuditEntityPullRequest AuditEntity = "PULL_REQUEST"
+)
+
+// AuditEntry — запись журнала аудита. Пишется в той же транзакции, что и изменение,
+// Before и After — JSON-снимки сущности до и после (null, если сущности ещё не было).
+type AuditEntry struct {
+	ID         int64
+	Action     AuditAction
+	EntityType AuditEntity
+	EntityID   string
+	Actor      string
+	RequestID  *string
+	Before     []byte
+	After      []byte
+	CreatedAt  time.Time
+}
+
+// AuditFilter — условия выборки журнала; nil-поля не ограничивают выборку.
+type AuditFilter struct {
+	EntityType *AuditEntity
+	EntityID   *string
+	Actor      *string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+type EventType = string
+
+const (
+	EventPullRequestCreated EventType = "pr.created"
+	EventReviewerAssigned   EventType = "reviewer.assigned"
+	EventReviewerReassigned EventType = "reviewer.reassigned"
+	EventPullRequestMerged  EventType = "pr.merged"
+)
+
+// Event — запись outbox. Пишется в той же транзакции, что и изменение, о котором сообщает,
+// поэтому подписчики узнают только о закоммиченных изменениях.
+type Event struct {
+	ID        int64
+	Type      EventType
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// Subscription — внешний адрес, на который рассылаются события выбранных типов.
+type Subscription struct {
+	ID       int
+	URL      string
+	Secret   string
+	Events   []EventType
+	IsActive bool
+}
+
+type DeliveryStatus = string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryFailed    DeliveryStatus = "FAILED"
+)
+
+// EventDelivery — доставка одного события одной подписке, запись журнала доставок.
+type EventDelivery struct {
+	ID             int64
+	SubscriptionID int
+	EventID        int64
+	EventType      EventType
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// PendingDelivery — доставка, взятая диспетчером в работу, вместе со всем, что нужно для отправки.
+type PendingDelivery struct {
+	ID       int64
+	Attempts int
+	URL      string
+	Secret   string
+	Event    Event
+}
+
+// DeliveryAttempt — результат очередной попытки доставки.
+// RetryIn задаёт, через сколько повторить попытку, если Status остаётся PENDING.
+type DeliveryAttempt struct {
+	Status     DeliveryStatus
+	StatusCode *int
+	Error      *string
+	RetryIn    time.Duration
+}
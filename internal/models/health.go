@@ -0,0 +1,25 @@
+package models
+
+type HealthStatus = string
+
+const (
+	HealthUp       HealthStatus = "UP"
+	HealthDegraded HealthStatus = "DEGRADED"
+	HealthDown     HealthStatus = "DOWN"
+)
+
+// HealthComponent — результат проверки одной зависимости. Error объясняет DOWN или DEGRADED
+// без внутренних подробностей, Details — числа, по которым принято решение.
+type HealthComponent struct {
+	Status  HealthStatus
+	Error   string
+	Details map[string]any
+}
+
+// Readiness — готовность принимать запросы. Status — худший из статусов компонентов;
+// во время остановки сервиса он DOWN независимо от проверок.
+type Readiness struct {
+	Status       HealthStatus
+	ShuttingDown bool
+	Components   map[string]HealthComponent
+}
@@ -7,6 +7,7 @@ type StatusPR = string
 const (
 	StatusOpen   StatusPR = "OPEN"
 	StatusMerged StatusPR = "MERGED"
+	StatusClosed StatusPR = "CLOSED"
 )
 
 type PullRequest struct {
@@ -15,5 +16,10 @@ type PullRequest struct {
 	AuthorID          string
 	Status            StatusPR
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
+	ReviewersRequired int
 	AssignedReviewers []string
+	Reviews           []Review
+	// Source — внешняя система, из которой пришёл PR; nil для PR, созданных через API.
+	Source *Provider
 }
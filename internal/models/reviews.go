@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type ReviewState = string
+
+const (
+	ReviewPending          ReviewState = "PENDING"
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+)
+
+type Review struct {
+	PullRequestID string
+	ReviewerID    string
+	State         ReviewState
+	Comment       *string
+	ReviewedAt    *time.Time
+}
+
+type Reassignment struct {
+	PullRequestID    string
+	OldReviewers     []string
+	NewReviewers     []string
+	MissingReviewers int
+}
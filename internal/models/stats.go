@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+type StatsFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName *string
+}
+
+type UserAssignmentStats struct {
+	UserID   string
+	Username string
+	TeamName string
+	Total    int
+	Open     int
+	Merged   int
+}
+
+type TeamPullRequestStats struct {
+	TeamName         string
+	Open             int
+	Merged           int
+	WithoutReviewers int
+	// AvgTimeToMerge в секундах, nil если за период не было merge.
+	AvgTimeToMerge *float64
+}
@@ -0,0 +1,26 @@
+package models
+
+type ReviewerStrategy = string
+
+const (
+	StrategyRandom      ReviewerStrategy = "RANDOM"
+	StrategyRoundRobin  ReviewerStrategy = "ROUND_ROBIN"
+	StrategyLeastLoaded ReviewerStrategy = "LEAST_LOADED"
+	StrategyWeighted    ReviewerStrategy = "WEIGHTED"
+)
+
+const (
+	DefaultReviewersRequired = 2
+	MaxReviewersRequired     = 10
+)
+
+type Team struct {
+	ID                int
+	Name              string
+	ReviewerStrategy  ReviewerStrategy
+	ReviewersRequired int
+	RequireApprovals  bool
+	LastReviewerID    *string
+	// ChatWebhookURL — incoming webhook Slack/Mattermost, куда уходят уведомления команды (nil — не отправлять).
+	ChatWebhookURL *string
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type Role = string
+
+const (
+	RoleAdmin Role = "ADMIN"
+	RoleUser  Role = "USER"
+)
+
+// APIToken — токен доступа к API. Хранится только SHA-256 хеш, сам токен показывается один раз при выпуске.
+type APIToken struct {
+	ID        int
+	Name      string
+	Role      Role
+	Hash      string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
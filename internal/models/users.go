@@ -5,4 +5,6 @@ type User struct {
 	Name     string
 	TeamID   int
 	IsActive bool
+	// MaxOpenReviews ограничивает число одновременных открытых ревью, nil — без ограничения.
+	MaxOpenReviews *int
 }
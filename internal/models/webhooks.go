@@ -0,0 +1,47 @@
+package models
+
+type Provider = string
+
+const (
+	ProviderGitHub Provider = "GITHUB"
+	ProviderGitLab Provider = "GITLAB"
+)
+
+// UserLogin связывает логин во внешней системе с пользователем сервиса.
+type UserLogin struct {
+	Provider Provider
+	Login    string
+	UserID   string
+}
+
+type WebhookAction = string
+
+const (
+	WebhookOpened   WebhookAction = "OPENED"
+	WebhookMerged   WebhookAction = "MERGED"
+	WebhookClosed   WebhookAction = "CLOSED"
+	WebhookReopened WebhookAction = "REOPENED"
+	WebhookReviewed WebhookAction = "REVIEWED"
+)
+
+// WebhookEvent — событие внешней системы, уже переведённое в операцию над PR.
+// Пользователи указаны логинами этой системы, в user_id их переводит сервис.
+type WebhookEvent struct {
+	Provider      Provider
+	DeliveryID    string
+	Action        WebhookAction
+	PullRequestID string
+	Title         string
+	AuthorLogin   string
+	ReviewerLogin string
+	ReviewState   ReviewState
+	Comment       *string
+}
+
+type WebhookResult = string
+
+const (
+	WebhookProcessed WebhookResult = "PROCESSED"
+	WebhookDuplicate WebhookResult = "DUPLICATE"
+	WebhookIgnored   WebhookResult = "IGNORED"
+)
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type AbsenceRepository struct{}
+
+func (r *AbsenceRepository) Create(ctx context.Context, db repository.DBTX, absence *models.Absence) (int, error) {
+	return query(ctx, db, func(st *state) (int, error) {
+		if _, ok := st.users[absence.UserID]; !ok {
+			return 0, fmt.Errorf("execute query: user %q does not exist", absence.UserID)
+		}
+
+		row := *absence
+		row.ID = st.nextAbsenceID
+		st.nextAbsenceID++
+		st.absences[row.ID] = row
+
+		return row.ID, nil
+	})
+}
+
+func (r *AbsenceRepository) GetByUserID(ctx context.Context, db repository.DBTX, userID string) ([]models.Absence, error) {
+	return query(ctx, db, func(st *state) ([]models.Absence, error) {
+		var absences []models.Absence
+		for _, absence := range st.absences {
+			if absence.UserID == userID {
+				absences = append(absences, absence)
+			}
+		}
+		sort.Slice(absences, func(i, j int) bool {
+			return absences[i].StartsAt.Before(absences[j].StartsAt)
+		})
+
+		return absences, nil
+	})
+}
+
+func (r *AbsenceRepository) Delete(ctx context.Context, db repository.DBTX, id int) error {
+	return exec(ctx, db, func(st *state) error {
+		if _, ok := st.absences[id]; !ok {
+			return apperrors.ErrNotFound
+		}
+
+		delete(st.absences, id)
+
+		return nil
+	})
+}
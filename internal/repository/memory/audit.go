@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type AuditRepository struct{}
+
+func (r *AuditRepository) Add(ctx context.Context, db repository.DBTX, entry *models.AuditEntry) error {
+	return r.AddBatch(ctx, db, []models.AuditEntry{*entry})
+}
+
+func (r *AuditRepository) AddBatch(ctx context.Context, db repository.DBTX, entries []models.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return exec(ctx, db, func(st *state) error {
+		createdAt := now()
+		for _, entry := range entries {
+			row := entry
+			row.ID = int64(len(st.audit) + 1)
+			row.Before = slices.Clone(entry.Before)
+			row.After = slices.Clone(entry.After)
+			row.CreatedAt = createdAt
+			st.audit = append(st.audit, row)
+		}
+
+		return nil
+	})
+}
+
+func (r *AuditRepository) List(ctx context.Context, db repository.DBTX, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	return query(ctx, db, func(st *state) ([]models.AuditEntry, error) {
+		var entries []models.AuditEntry
+		for i := len(st.audit) - 1; i >= 0 && len(entries) < filter.Limit; i-- {
+			e := st.audit[i]
+			if filter.EntityType != nil && e.EntityType != *filter.EntityType ||
+				filter.EntityID != nil && e.EntityID != *filter.EntityID ||
+				filter.Actor != nil && e.Actor != *filter.Actor ||
+				filter.From != nil && e.CreatedAt.Before(*filter.From) ||
+				filter.To != nil && !e.CreatedAt.Before(*filter.To) {
+				continue
+			}
+			entries = append(entries, e)
+		}
+
+		return entries, nil
+	})
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type DeliveryRepository struct{}
+
+func (r *DeliveryRepository) Exists(
+	ctx context.Context,
+	db repository.DBTX,
+	provider models.Provider,
+	deliveryID string,
+) (bool, error) {
+	return query(ctx, db, func(st *state) (bool, error) {
+		_, ok := st.deliveries[deliveryKey{provider: provider, deliveryID: deliveryID}]
+		return ok, nil
+	})
+}
+
+func (r *DeliveryRepository) Save(
+	ctx context.Context,
+	db repository.DBTX,
+	provider models.Provider,
+	deliveryID string,
+	_ models.WebhookAction,
+) (bool, error) {
+	return query(ctx, db, func(st *state) (bool, error) {
+		key := deliveryKey{provider: provider, deliveryID: deliveryID}
+		if _, ok := st.deliveries[key]; ok {
+			return false, nil
+		}
+
+		st.deliveries[key] = struct{}{}
+		return true, nil
+	})
+}
@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type EventDeliveryRepository struct{}
+
+func (r *EventDeliveryRepository) CreateBatch(ctx context.Context, db repository.DBTX, deliveries []models.EventDelivery) error {
+	return exec(ctx, db, func(st *state) error {
+		for _, delivery := range deliveries {
+			if _, ok := st.subscriptions[delivery.SubscriptionID]; !ok {
+				return fmt.Errorf("insert event deliveries: subscription %d does not exist", delivery.SubscriptionID)
+			}
+			if _, ok := st.outbox[delivery.EventID]; !ok {
+				return fmt.Errorf("insert event deliveries: event %d does not exist", delivery.EventID)
+			}
+		}
+
+		createdAt := now()
+		for _, delivery := range deliveries {
+			if hasDelivery(st, delivery.SubscriptionID, delivery.EventID) {
+				continue
+			}
+
+			id := st.nextEventDeliveryID
+			st.nextEventDeliveryID++
+			st.eventDeliveries[id] = models.EventDelivery{
+				ID:             id,
+				SubscriptionID: delivery.SubscriptionID,
+				EventID:        delivery.EventID,
+				EventType:      st.outbox[delivery.EventID].Type,
+				Status:         models.DeliveryPending,
+				NextAttemptAt:  createdAt,
+				CreatedAt:      createdAt,
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *EventDeliveryRepository) ClaimDue(
+	ctx context.Context,
+	db repository.DBTX,
+	lease time.Duration,
+	limit int,
+) ([]models.PendingDelivery, error) {
+	return query(ctx, db, func(st *state) ([]models.PendingDelivery, error) {
+		at := now()
+
+		var due []models.EventDelivery
+		for _, delivery := range st.eventDeliveries {
+			if delivery.Status == models.DeliveryPending && !delivery.NextAttemptAt.After(at) &&
+				st.subscriptions[delivery.SubscriptionID].IsActive {
+				due = append(due, delivery)
+			}
+		}
+		sort.Slice(due, func(i, j int) bool {
+			if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+				return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+			}
+			return due[i].ID < due[j].ID
+		})
+		due = due[:min(limit, len(due))]
+
+		claimed := make([]models.PendingDelivery, 0, len(due))
+		for _, delivery := range due {
+			sub := st.subscriptions[delivery.SubscriptionID]
+			claimed = append(claimed, models.PendingDelivery{
+				ID:       delivery.ID,
+				Attempts: delivery.Attempts,
+				URL:      sub.URL,
+				Secret:   sub.Secret,
+				Event:    st.outbox[delivery.EventID].Event,
+			})
+
+			delivery.NextAttemptAt = at.Add(lease)
+			st.eventDeliveries[delivery.ID] = delivery
+		}
+
+		return claimed, nil
+	})
+}
+
+func (r *EventDeliveryRepository) SaveAttempt(
+	ctx context.Context,
+	db repository.DBTX,
+	id int64,
+	attempt *models.DeliveryAttempt,
+) error {
+	return exec(ctx, db, func(st *state) error {
+		delivery, ok := st.eventDeliveries[id]
+		if !ok {
+			return nil
+		}
+
+		at := now()
+		delivery.Attempts++
+		delivery.Status = attempt.Status
+		delivery.LastStatusCode = attempt.StatusCode
+		delivery.LastError = attempt.Error
+		switch attempt.Status {
+		case models.DeliveryDelivered:
+			delivery.DeliveredAt = &at
+		case models.DeliveryPending:
+			delivery.NextAttemptAt = at.Add(attempt.RetryIn)
+		}
+		st.eventDeliveries[id] = delivery
+
+		return nil
+	})
+}
+
+func (r *EventDeliveryRepository) GetBySubscription(
+	ctx context.Context,
+	db repository.DBTX,
+	subscriptionID int,
+	limit int,
+) ([]models.EventDelivery, error) {
+	return query(ctx, db, func(st *state) ([]models.EventDelivery, error) {
+		var deliveries []models.EventDelivery
+		for _, delivery := range st.eventDeliveries {
+			if delivery.SubscriptionID == subscriptionID {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		sort.Slice(deliveries, func(i, j int) bool {
+			return deliveries[i].ID > deliveries[j].ID
+		})
+
+		return deliveries[:min(limit, len(deliveries))], nil
+	})
+}
+
+func hasDelivery(st *state, subscriptionID int, eventID int64) bool {
+	for _, delivery := range st.eventDeliveries {
+		if delivery.SubscriptionID == subscriptionID && delivery.EventID == eventID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type LoginRepository struct{}
+
+func (r *LoginRepository) Link(ctx context.Context, db repository.DBTX, login *models.UserLogin) error {
+	return exec(ctx, db, func(st *state) error {
+		if _, ok := st.users[login.UserID]; !ok {
+			return fmt.Errorf("execute query: user %q does not exist", login.UserID)
+		}
+
+		st.logins[loginKey{provider: login.Provider, login: login.Login}] = login.UserID
+
+		return nil
+	})
+}
+
+func (r *LoginRepository) Unlink(
+	ctx context.Context,
+	db repository.DBTX,
+	provider models.Provider,
+	login string,
+) (*models.UserLogin, error) {
+	return query(ctx, db, func(st *state) (*models.UserLogin, error) {
+		key := loginKey{provider: provider, login: login}
+		userID, ok := st.logins[key]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		delete(st.logins, key)
+
+		return &models.UserLogin{Provider: provider, Login: login, UserID: userID}, nil
+	})
+}
+
+func (r *LoginRepository) Resolve(ctx context.Context, db repository.DBTX, provider models.Provider, login string) (string, error) {
+	return query(ctx, db, func(st *state) (string, error) {
+		userID, ok := st.logins[loginKey{provider: provider, login: login}]
+		if !ok {
+			return "", apperrors.ErrNotFound
+		}
+
+		return userID, nil
+	})
+}
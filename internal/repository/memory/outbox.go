@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type OutboxRepository struct{}
+
+func (r *OutboxRepository) Add(ctx context.Context, db repository.DBTX, eventType models.EventType, payload []byte) error {
+	return exec(ctx, db, func(st *state) error {
+		id := st.nextEventID
+		st.nextEventID++
+		st.outbox[id] = outboxRow{
+			Event: models.Event{
+				ID:        id,
+				Type:      eventType,
+				Payload:   slices.Clone(payload),
+				CreatedAt: now(),
+			},
+		}
+
+		return nil
+	})
+}
+
+func (r *OutboxRepository) GetUndispatched(ctx context.Context, db repository.DBTX, limit int) ([]models.Event, error) {
+	return query(ctx, db, func(st *state) ([]models.Event, error) {
+		var events []models.Event
+		for _, row := range st.outbox {
+			if row.DispatchedAt == nil {
+				events = append(events, row.Event)
+			}
+		}
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].ID < events[j].ID
+		})
+
+		return events[:min(limit, len(events))], nil
+	})
+}
+
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, db repository.DBTX, ids []int64) error {
+	return exec(ctx, db, func(st *state) error {
+		dispatchedAt := now()
+		for _, id := range ids {
+			if row, ok := st.outbox[id]; ok {
+				row.DispatchedAt = &dispatchedAt
+				st.outbox[id] = row
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type PullRequestRepository struct{}
+
+func (r *PullRequestRepository) Create(ctx context.Context, db repository.DBTX, pr *models.PullRequest) error {
+	return exec(ctx, db, func(st *state) error {
+		if _, ok := st.pullRequests[pr.ID]; ok {
+			return fmt.Errorf("exec create pr: pull request %q already exists", pr.ID)
+		}
+		if _, ok := st.users[pr.AuthorID]; !ok {
+			return fmt.Errorf("exec create pr: author %q does not exist", pr.AuthorID)
+		}
+
+		st.pullRequests[pr.ID] = pullRequestRow{
+			PullRequest: models.PullRequest{
+				ID:                pr.ID,
+				Title:             pr.Title,
+				AuthorID:          pr.AuthorID,
+				Status:            models.StatusOpen,
+				ReviewersRequired: pr.ReviewersRequired,
+				Source:            pr.Source,
+			},
+			CreatedAt: now(),
+		}
+
+		return nil
+	})
+}
+
+func (r *PullRequestRepository) GetByID(ctx context.Context, db repository.DBTX, id string) (*models.PullRequest, error) {
+	return query(ctx, db, func(st *state) (*models.PullRequest, error) {
+		row, ok := st.pullRequests[id]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		pr := row.PullRequest
+		return &pr, nil
+	})
+}
+
+func (r *PullRequestRepository) Exists(ctx context.Context, db repository.DBTX, id string) (bool, error) {
+	return query(ctx, db, func(st *state) (bool, error) {
+		_, ok := st.pullRequests[id]
+		return ok, nil
+	})
+}
+
+func (r *PullRequestRepository) UpdateMergeStatus(ctx context.Context, db repository.DBTX, id string) error {
+	return exec(ctx, db, func(st *state) error {
+		row, ok := st.pullRequests[id]
+		if !ok || row.Status != models.StatusOpen {
+			return nil
+		}
+
+		mergedAt := now()
+		row.Status = models.StatusMerged
+		row.MergedAt = &mergedAt
+		st.pullRequests[id] = row
+
+		return nil
+	})
+}
+
+func (r *PullRequestRepository) UpdateCloseStatus(ctx context.Context, db repository.DBTX, id string) error {
+	return exec(ctx, db, func(st *state) error {
+		row, ok := st.pullRequests[id]
+		if !ok || row.Status != models.StatusOpen {
+			return nil
+		}
+
+		closedAt := now()
+		row.Status = models.StatusClosed
+		row.ClosedAt = &closedAt
+		st.pullRequests[id] = row
+
+		return nil
+	})
+}
+
+func (r *PullRequestRepository) Reopen(ctx context.Context, db repository.DBTX, id string) error {
+	return exec(ctx, db, func(st *state) error {
+		row, ok := st.pullRequests[id]
+		if !ok || row.Status != models.StatusClosed {
+			return nil
+		}
+
+		row.Status = models.StatusOpen
+		row.ClosedAt = nil
+		st.pullRequests[id] = row
+
+		return nil
+	})
+}
+
+func (r *PullRequestRepository) GetAssignedForUser(
+	ctx context.Context,
+	db repository.DBTX,
+	userID string,
+) ([]models.PullRequest, error) {
+	return query(ctx, db, func(st *state) ([]models.PullRequest, error) {
+		var result []models.PullRequest
+		for key := range st.reviews {
+			if key.reviewerID != userID {
+				continue
+			}
+
+			row := st.pullRequests[key.prID]
+			if row.Status == models.StatusClosed {
+				continue
+			}
+			result = append(result, models.PullRequest{
+				ID:       row.ID,
+				Title:    row.Title,
+				AuthorID: row.AuthorID,
+				Status:   row.Status,
+			})
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].ID < result[j].ID
+		})
+
+		return result, nil
+	})
+}
+
+func (r *PullRequestRepository) GetOpenReviewedBy(
+	ctx context.Context,
+	db repository.DBTX,
+	reviewerIDs []string,
+) ([]models.PullRequest, error) {
+	return query(ctx, db, func(st *state) ([]models.PullRequest, error) {
+		prIDs := make(map[string]struct{})
+		for _, reviewerID := range reviewerIDs {
+			for key := range st.reviews {
+				if key.reviewerID == reviewerID && st.pullRequests[key.prID].Status == models.StatusOpen {
+					prIDs[key.prID] = struct{}{}
+				}
+			}
+		}
+
+		result := make([]models.PullRequest, 0, len(prIDs))
+		for prID := range prIDs {
+			row := st.pullRequests[prID]
+			pr := models.PullRequest{
+				ID:                row.ID,
+				Title:             row.Title,
+				AuthorID:          row.AuthorID,
+				Status:            row.Status,
+				ReviewersRequired: row.ReviewersRequired,
+			}
+			for _, review := range st.reviewsByPR(prID) {
+				pr.AssignedReviewers = append(pr.AssignedReviewers, review.ReviewerID)
+			}
+			result = append(result, pr)
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].ID < result[j].ID
+		})
+
+		return result, nil
+	})
+}
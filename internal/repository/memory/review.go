@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type ReviewRepository struct{}
+
+func (r *ReviewRepository) Assign(ctx context.Context, db repository.DBTX, prID string, reviewerIDs ...string) error {
+	reviews := make([]models.Review, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		reviews[i] = models.Review{PullRequestID: prID, ReviewerID: reviewerID}
+	}
+
+	return r.AssignBatch(ctx, db, reviews)
+}
+
+func (r *ReviewRepository) GetReviewersByPR(ctx context.Context, db repository.DBTX, prID string) ([]string, error) {
+	return query(ctx, db, func(st *state) ([]string, error) {
+		var reviewerIDs []string
+		for _, review := range st.reviewsByPR(prID) {
+			reviewerIDs = append(reviewerIDs, review.ReviewerID)
+		}
+
+		return reviewerIDs, nil
+	})
+}
+
+func (r *ReviewRepository) GetByPR(ctx context.Context, db repository.DBTX, prID string) ([]models.Review, error) {
+	return query(ctx, db, func(st *state) ([]models.Review, error) {
+		return st.reviewsByPR(prID), nil
+	})
+}
+
+func (r *ReviewRepository) UpdateState(ctx context.Context, db repository.DBTX, review *models.Review) error {
+	return exec(ctx, db, func(st *state) error {
+		key := reviewKey{prID: review.PullRequestID, reviewerID: review.ReviewerID}
+		row, ok := st.reviews[key]
+		if !ok {
+			return apperrors.ErrNotAssigned
+		}
+
+		reviewedAt := now()
+		row.State = review.State
+		row.Comment = review.Comment
+		row.ReviewedAt = &reviewedAt
+		st.reviews[key] = row
+
+		return nil
+	})
+}
+
+func (r *ReviewRepository) Delete(ctx context.Context, db repository.DBTX, prID, oldReviewerID string) error {
+	return exec(ctx, db, func(st *state) error {
+		key := reviewKey{prID: prID, reviewerID: oldReviewerID}
+		if _, ok := st.reviews[key]; !ok {
+			return apperrors.ErrNotFound
+		}
+
+		delete(st.reviews, key)
+
+		return nil
+	})
+}
+
+func (r *ReviewRepository) CountOpenByReviewers(
+	ctx context.Context,
+	db repository.DBTX,
+	reviewerIDs []string,
+) (map[string]int, error) {
+	return query(ctx, db, func(st *state) (map[string]int, error) {
+		wanted := make(map[string]struct{}, len(reviewerIDs))
+		for _, id := range reviewerIDs {
+			wanted[id] = struct{}{}
+		}
+
+		openReviews := make(map[string]int, len(reviewerIDs))
+		for key := range st.reviews {
+			if _, ok := wanted[key.reviewerID]; !ok {
+				continue
+			}
+			if st.pullRequests[key.prID].Status == models.StatusOpen {
+				openReviews[key.reviewerID]++
+			}
+		}
+
+		return openReviews, nil
+	})
+}
+
+func (r *ReviewRepository) AssignBatch(ctx context.Context, db repository.DBTX, reviews []models.Review) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	return exec(ctx, db, func(st *state) error {
+		seen := make(map[reviewKey]struct{}, len(reviews))
+		for _, review := range reviews {
+			key := reviewKey{prID: review.PullRequestID, reviewerID: review.ReviewerID}
+			if _, ok := st.pullRequests[key.prID]; !ok {
+				return fmt.Errorf("insert reviewers: pull request %q does not exist", key.prID)
+			}
+			if _, ok := st.users[key.reviewerID]; !ok {
+				return fmt.Errorf("insert reviewers: user %q does not exist", key.reviewerID)
+			}
+			if _, ok := st.reviews[key]; ok {
+				return fmt.Errorf("insert reviewers: %q is already a reviewer of %q", key.reviewerID, key.prID)
+			}
+			if _, ok := seen[key]; ok {
+				return fmt.Errorf("insert reviewers: duplicate reviewer %q for %q", key.reviewerID, key.prID)
+			}
+			seen[key] = struct{}{}
+		}
+
+		for key := range seen {
+			st.reviews[key] = models.Review{
+				PullRequestID: key.prID,
+				ReviewerID:    key.reviewerID,
+				State:         models.ReviewPending,
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *ReviewRepository) DeleteByReviewers(ctx context.Context, db repository.DBTX, prIDs, reviewerIDs []string) error {
+	return exec(ctx, db, func(st *state) error {
+		for _, prID := range prIDs {
+			for _, reviewerID := range reviewerIDs {
+				delete(st.reviews, reviewKey{prID: prID, reviewerID: reviewerID})
+			}
+		}
+
+		return nil
+	})
+}
+
+func (st *state) reviewsByPR(prID string) []models.Review {
+	var reviews []models.Review
+	for key, review := range st.reviews {
+		if key.prID == prID {
+			reviews = append(reviews, review)
+		}
+	}
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].ReviewerID < reviews[j].ReviewerID
+	})
+
+	return reviews
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type StatsRepository struct{}
+
+func (r *StatsRepository) GetAssignments(
+	ctx context.Context,
+	db repository.DBTX,
+	filter models.StatsFilter,
+) ([]models.UserAssignmentStats, error) {
+	return query(ctx, db, func(st *state) ([]models.UserAssignmentStats, error) {
+		byUser := make(map[string]*models.UserAssignmentStats)
+		var stats []*models.UserAssignmentStats
+		for _, user := range st.sortedUsers() {
+			team := st.teams[user.TeamID]
+			if filter.TeamName != nil && team.Name != *filter.TeamName {
+				continue
+			}
+
+			s := &models.UserAssignmentStats{UserID: user.ID, Username: user.Name, TeamName: team.Name}
+			byUser[user.ID] = s
+			stats = append(stats, s)
+		}
+
+		for key := range st.reviews {
+			s, ok := byUser[key.reviewerID]
+			pr := st.pullRequests[key.prID]
+			if !ok || !inPeriod(pr, filter) {
+				continue
+			}
+
+			s.Total++
+			switch pr.Status {
+			case models.StatusOpen:
+				s.Open++
+			case models.StatusMerged:
+				s.Merged++
+			}
+		}
+
+		result := make([]models.UserAssignmentStats, len(stats))
+		for i, s := range stats {
+			result[i] = *s
+		}
+
+		return result, nil
+	})
+}
+
+func (r *StatsRepository) GetPullRequests(
+	ctx context.Context,
+	db repository.DBTX,
+	filter models.StatsFilter,
+) ([]models.TeamPullRequestStats, error) {
+	return query(ctx, db, func(st *state) ([]models.TeamPullRequestStats, error) {
+		type teamTotals struct {
+			stats      models.TeamPullRequestStats
+			mergeTotal float64
+			mergeCount int
+		}
+
+		byTeam := make(map[int]*teamTotals)
+		for id, team := range st.teams {
+			if filter.TeamName != nil && team.Name != *filter.TeamName {
+				continue
+			}
+			byTeam[id] = &teamTotals{stats: models.TeamPullRequestStats{TeamName: team.Name}}
+		}
+
+		withReviewers := make(map[string]struct{})
+		for key := range st.reviews {
+			withReviewers[key.prID] = struct{}{}
+		}
+
+		for _, pr := range st.pullRequests {
+			totals, ok := byTeam[st.users[pr.AuthorID].TeamID]
+			if !ok || !inPeriod(pr, filter) {
+				continue
+			}
+
+			switch pr.Status {
+			case models.StatusOpen:
+				totals.stats.Open++
+			case models.StatusMerged:
+				totals.stats.Merged++
+			}
+			if _, ok := withReviewers[pr.ID]; !ok {
+				totals.stats.WithoutReviewers++
+			}
+			if pr.MergedAt != nil {
+				totals.mergeTotal += pr.MergedAt.Sub(pr.CreatedAt).Seconds()
+				totals.mergeCount++
+			}
+		}
+
+		result := make([]models.TeamPullRequestStats, 0, len(byTeam))
+		for _, totals := range byTeam {
+			if totals.mergeCount > 0 {
+				avg := totals.mergeTotal / float64(totals.mergeCount)
+				totals.stats.AvgTimeToMerge = &avg
+			}
+			result = append(result, totals.stats)
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].TeamName < result[j].TeamName
+		})
+
+		return result, nil
+	})
+}
+
+// inPeriod повторяет окно по created_at из Postgres-реализации: from включительно, to не включительно.
+func inPeriod(pr pullRequestRow, filter models.StatsFilter) bool {
+	if filter.From != nil && pr.CreatedAt.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && !pr.CreatedAt.Before(*filter.To) {
+		return false
+	}
+
+	return true
+}
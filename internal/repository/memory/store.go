@@ -0,0 +1,273 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+var errTxClosed = errors.New("memory: transaction is already closed")
+
+// Store хранит все данные сервиса в памяти процесса и реализует database.Storage.
+//
+// Транзакции выполняются строго по одной: BeginTx захватывает хранилище целиком и работает
+// с копией состояния, Commit подменяет состояние копией, Rollback просто её выбрасывает.
+// Последовательное выполнение даёт ту же гарантию, что и serializable в Postgres,
+// только без ошибок сериализации. Запросы вне транзакции ждут её завершения.
+//
+// Копирование всех таблиц в BeginTx делает каждую транзакцию O(объёма данных). Это сознательная цена:
+// хранилище рассчитано на тесты и локальный запуск с небольшими данными, а копия даёт откат
+// без журнала изменений и без риска, что недоделанная транзакция станет видна другим.
+type Store struct {
+	lock  chan struct{}
+	state *state
+}
+
+func NewStore() *Store {
+	return &Store{
+		lock:  make(chan struct{}, 1),
+		state: newState(),
+	}
+}
+
+func (s *Store) Conn() database.Conn {
+	return s
+}
+
+func (s *Store) StorageName() string {
+	return config.StorageMemory
+}
+
+func (s *Store) BeginTx(ctx context.Context) (database.Tx, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	return &Tx{store: s, state: s.state.clone()}, nil
+}
+
+func (s *Store) HealthCheck(context.Context) error {
+	return nil
+}
+
+func (s *Store) SchemaVersion(context.Context) (uint, bool, error) {
+	return 0, false, database.ErrNoMigrations
+}
+
+// PoolStats: пула соединений у хранилища в памяти нет.
+func (s *Store) PoolStats() database.PoolStats {
+	return database.PoolStats{}
+}
+
+func (s *Store) Close() {}
+
+func (s *Store) acquire(ctx context.Context) error {
+	select {
+	case s.lock <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for memory storage: %w", ctx.Err())
+	}
+}
+
+func (s *Store) release() {
+	<-s.lock
+}
+
+type Tx struct {
+	store  *Store
+	state  *state
+	closed bool
+}
+
+func (*Tx) StorageName() string {
+	return config.StorageMemory
+}
+
+func (tx *Tx) Commit(context.Context) error {
+	if tx.closed {
+		return errTxClosed
+	}
+
+	tx.store.state = tx.state
+	tx.closed = true
+	tx.store.release()
+
+	return nil
+}
+
+func (tx *Tx) Rollback(context.Context) error {
+	if tx.closed {
+		return nil
+	}
+
+	tx.closed = true
+	tx.store.release()
+
+	return nil
+}
+
+type reviewKey struct {
+	prID       string
+	reviewerID string
+}
+
+type loginKey struct {
+	provider models.Provider
+	login    string
+}
+
+type deliveryKey struct {
+	provider   models.Provider
+	deliveryID string
+}
+
+type outboxRow struct {
+	models.Event
+	DispatchedAt *time.Time
+}
+
+type pullRequestRow struct {
+	models.PullRequest
+	CreatedAt time.Time
+}
+
+type state struct {
+	teams         map[int]models.Team
+	nextTeamID    int
+	users         map[string]models.User
+	pullRequests  map[string]pullRequestRow
+	reviews       map[reviewKey]models.Review
+	absences      map[int]models.Absence
+	nextAbsenceID int
+	logins        map[loginKey]string
+	deliveries    map[deliveryKey]struct{}
+
+	subscriptions       map[int]models.Subscription
+	nextSubscriptionID  int
+	outbox              map[int64]outboxRow
+	nextEventID         int64
+	eventDeliveries     map[int64]models.EventDelivery
+	nextEventDeliveryID int64
+
+	tokens      map[int]models.APIToken
+	nextTokenID int
+
+	audit []models.AuditEntry
+}
+
+func newState() *state {
+	return &state{
+		teams:         make(map[int]models.Team),
+		nextTeamID:    1,
+		users:         make(map[string]models.User),
+		pullRequests:  make(map[string]pullRequestRow),
+		reviews:       make(map[reviewKey]models.Review),
+		absences:      make(map[int]models.Absence),
+		nextAbsenceID: 1,
+		logins:        make(map[loginKey]string),
+		deliveries:    make(map[deliveryKey]struct{}),
+
+		subscriptions:       make(map[int]models.Subscription),
+		nextSubscriptionID:  1,
+		outbox:              make(map[int64]outboxRow),
+		nextEventID:         1,
+		eventDeliveries:     make(map[int64]models.EventDelivery),
+		nextEventDeliveryID: 1,
+
+		tokens:      make(map[int]models.APIToken),
+		nextTokenID: 1,
+	}
+}
+
+// clone копирует таблицы. Строки хранятся по значению, а поля-указатели в них
+// только заменяются, но не изменяются на месте, поэтому поверхностной копии достаточно.
+// Журнал аудита тоже копируется: append в общий массив из откатившейся транзакции
+// мог бы записать строку в запас ёмкости, который общее состояние займёт следующим.
+func (st *state) clone() *state {
+	return &state{
+		teams:         maps.Clone(st.teams),
+		nextTeamID:    st.nextTeamID,
+		users:         maps.Clone(st.users),
+		pullRequests:  maps.Clone(st.pullRequests),
+		reviews:       maps.Clone(st.reviews),
+		absences:      maps.Clone(st.absences),
+		nextAbsenceID: st.nextAbsenceID,
+		logins:        maps.Clone(st.logins),
+		deliveries:    maps.Clone(st.deliveries),
+
+		subscriptions:       maps.Clone(st.subscriptions),
+		nextSubscriptionID:  st.nextSubscriptionID,
+		outbox:              maps.Clone(st.outbox),
+		nextEventID:         st.nextEventID,
+		eventDeliveries:     maps.Clone(st.eventDeliveries),
+		nextEventDeliveryID: st.nextEventDeliveryID,
+
+		tokens:      maps.Clone(st.tokens),
+		nextTokenID: st.nextTokenID,
+
+		audit: slices.Clone(st.audit),
+	}
+}
+
+// query выполняет fn над состоянием соединения: внутри транзакции — над её копией,
+// вне транзакции — над общим состоянием под блокировкой хранилища. Вне транзакции fn
+// должна проверять все условия до первого изменения, откатить частичную запись будет нечем.
+func query[T any](ctx context.Context, db repository.DBTX, fn func(st *state) (T, error)) (T, error) {
+	var zero T
+
+	switch conn := db.(type) {
+	case *Tx:
+		if conn.closed {
+			return zero, errTxClosed
+		}
+		return fn(conn.state)
+	case *Store:
+		if err := conn.acquire(ctx); err != nil {
+			return zero, err
+		}
+		defer conn.release()
+		return fn(conn.state)
+	default:
+		return zero, repository.ForeignConnError(config.StorageMemory, db)
+	}
+}
+
+func exec(ctx context.Context, db repository.DBTX, fn func(st *state) error) error {
+	_, err := query(ctx, db, func(st *state) (struct{}, error) {
+		return struct{}{}, fn(st)
+	})
+
+	return err
+}
+
+// now возвращает текущее время без зоны, как NOW() для колонок TIMESTAMP в Postgres.
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+func NewRepository() *repository.Repository {
+	return &repository.Repository{
+		TeamRepository:          &TeamRepository{},
+		UserRepository:          &UserRepository{},
+		ReviewRepository:        &ReviewRepository{},
+		PullRequestRepository:   &PullRequestRepository{},
+		StatsRepository:         &StatsRepository{},
+		AbsenceRepository:       &AbsenceRepository{},
+		LoginRepository:         &LoginRepository{},
+		DeliveryRepository:      &DeliveryRepository{},
+		SubscriptionRepository:  &SubscriptionRepository{},
+		OutboxRepository:        &OutboxRepository{},
+		EventDeliveryRepository: &EventDeliveryRepository{},
+		TokenRepository:         &TokenRepository{},
+		AuditRepository:         &AuditRepository{},
+	}
+}
@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+)
+
+// foreignConn — соединение чужого хранилища.
+type foreignConn struct{}
+
+func (foreignConn) StorageName() string {
+	return config.StoragePostgres
+}
+
+func inTx(t *testing.T, store *Store, commit bool, fn func(tx database.Tx)) {
+	t.Helper()
+
+	tx, err := store.BeginTx(context.Background())
+	if err != nil {
+		t.Fatalf("begin tx: %v", err)
+	}
+
+	fn(tx)
+
+	if commit {
+		err = tx.Commit(context.Background())
+	} else {
+		err = tx.Rollback(context.Background())
+	}
+	if err != nil {
+		t.Fatalf("finish tx: %v", err)
+	}
+}
+
+func addAudit(t *testing.T, repo *repository.Repository, db repository.DBTX, entityIDs ...string) {
+	t.Helper()
+
+	entries := make([]models.AuditEntry, len(entityIDs))
+	for i, id := range entityIDs {
+		entries[i] = models.AuditEntry{Action: models.AuditTeamCreated, EntityType: models.AuditEntityTeam, EntityID: id}
+	}
+	if err := repo.AuditRepository.AddBatch(context.Background(), db, entries); err != nil {
+		t.Fatalf("add audit: %v", err)
+	}
+}
+
+func TestTxRollbackDiscardsChanges(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewRepository()
+
+	// закоммиченные записи оставляют в срезе журнала запас ёмкости
+	inTx(t, store, true, func(tx database.Tx) {
+		if _, err := repo.TeamRepository.Create(ctx, tx, &models.Team{Name: "backend"}); err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+		addAudit(t, repo, tx, "backend", "a", "b")
+	})
+
+	inTx(t, store, false, func(tx database.Tx) {
+		if _, err := repo.TeamRepository.Create(ctx, tx, &models.Team{Name: "frontend"}); err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+		addAudit(t, repo, tx, "rolled-back")
+	})
+
+	inTx(t, store, true, func(tx database.Tx) {
+		addAudit(t, repo, tx, "committed")
+	})
+
+	if _, err := repo.TeamRepository.GetByName(ctx, store.Conn(), "frontend"); !errors.Is(err, apperrors.ErrNotFound) {
+		t.Errorf("GetByName(frontend) error = %v, want ErrNotFound after rollback", err)
+	}
+
+	entries, err := repo.AuditRepository.List(ctx, store.Conn(), models.AuditFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("list audit: %v", err)
+	}
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.EntityID)
+	}
+	want := []string{"committed", "b", "a", "backend"}
+	if len(got) != len(want) {
+		t.Fatalf("audit = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("audit = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTxIsolation(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	repo := NewRepository()
+
+	tx, err := store.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("begin tx: %v", err)
+	}
+
+	teamID, err := repo.TeamRepository.Create(ctx, tx, &models.Team{Name: "backend"})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	if err = repo.UserRepository.Create(ctx, tx, teamID, &api.TeamMember{UserId: "u1", Username: "alice", IsActive: true}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	// пока транзакция открыта, другие транзакции и запросы вне транзакции её ждут
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err = store.BeginTx(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("concurrent BeginTx error = %v, want DeadlineExceeded", err)
+	}
+	if _, err = repo.UserRepository.Exists(waitCtx, store.Conn(), "u1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Exists outside tx error = %v, want DeadlineExceeded", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	exists, err := repo.UserRepository.Exists(ctx, store.Conn(), "u1")
+	if err != nil || !exists {
+		t.Fatalf("Exists(u1) = %v, %v; want true after commit", exists, err)
+	}
+
+	// закрытая транзакция больше не принимает запросов
+	if _, err = repo.UserRepository.Exists(ctx, tx, "u1"); err == nil {
+		t.Error("query on committed tx succeeded, want error")
+	}
+	if err = tx.Commit(ctx); err == nil {
+		t.Error("second Commit succeeded, want error")
+	}
+}
+
+func TestForeignConn(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository()
+
+	tests := []struct {
+		name string
+		db   repository.DBTX
+	}{
+		{name: "other storage", db: foreignConn{}},
+		{name: "nil connection", db: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := repo.UserRepository.Exists(ctx, tt.db, "u1"); !errors.Is(err, repository.ErrForeignConn) {
+				t.Errorf("Exists() error = %v, want ErrForeignConn", err)
+			}
+			if _, err := repo.TeamRepository.Create(ctx, tt.db, &models.Team{Name: "backend"}); !errors.Is(err, repository.ErrForeignConn) {
+				t.Errorf("Create() error = %v, want ErrForeignConn", err)
+			}
+		})
+	}
+}
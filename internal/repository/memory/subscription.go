@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type SubscriptionRepository struct{}
+
+func (r *SubscriptionRepository) Create(ctx context.Context, db repository.DBTX, sub *models.Subscription) (int, error) {
+	return query(ctx, db, func(st *state) (int, error) {
+		row := *sub
+		row.ID = st.nextSubscriptionID
+		row.Events = sortedEvents(sub.Events)
+		st.nextSubscriptionID++
+		st.subscriptions[row.ID] = row
+
+		return row.ID, nil
+	})
+}
+
+func (r *SubscriptionRepository) GetByID(ctx context.Context, db repository.DBTX, id int) (*models.Subscription, error) {
+	return query(ctx, db, func(st *state) (*models.Subscription, error) {
+		sub, ok := st.subscriptions[id]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return &sub, nil
+	})
+}
+
+func (r *SubscriptionRepository) List(ctx context.Context, db repository.DBTX) ([]models.Subscription, error) {
+	return query(ctx, db, func(st *state) ([]models.Subscription, error) {
+		var subs []models.Subscription
+		for _, sub := range st.subscriptions {
+			subs = append(subs, sub)
+		}
+		sort.Slice(subs, func(i, j int) bool {
+			return subs[i].ID < subs[j].ID
+		})
+
+		return subs, nil
+	})
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, db repository.DBTX, sub *models.Subscription) error {
+	return exec(ctx, db, func(st *state) error {
+		row, ok := st.subscriptions[sub.ID]
+		if !ok {
+			return apperrors.ErrNotFound
+		}
+
+		row.URL = sub.URL
+		row.IsActive = sub.IsActive
+		row.Events = sortedEvents(sub.Events)
+		st.subscriptions[sub.ID] = row
+
+		return nil
+	})
+}
+
+func (r *SubscriptionRepository) Delete(ctx context.Context, db repository.DBTX, id int) error {
+	return exec(ctx, db, func(st *state) error {
+		if _, ok := st.subscriptions[id]; !ok {
+			return apperrors.ErrNotFound
+		}
+
+		delete(st.subscriptions, id)
+		for deliveryID, delivery := range st.eventDeliveries {
+			if delivery.SubscriptionID == id {
+				delete(st.eventDeliveries, deliveryID)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *SubscriptionRepository) GetActiveIDsByEvent(
+	ctx context.Context,
+	db repository.DBTX,
+	eventType models.EventType,
+) ([]int, error) {
+	return query(ctx, db, func(st *state) ([]int, error) {
+		var ids []int
+		for id, sub := range st.subscriptions {
+			if sub.IsActive && slices.Contains(sub.Events, eventType) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Ints(ids)
+
+		return ids, nil
+	})
+}
+
+// sortedEvents копирует список событий, чтобы строка хранилища не делила его с вызывающим.
+func sortedEvents(events []models.EventType) []models.EventType {
+	sorted := slices.Clone(events)
+	sort.Strings(sorted)
+
+	return slices.Compact(sorted)
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type TeamRepository struct{}
+
+func (r *TeamRepository) Create(ctx context.Context, db repository.DBTX, team *models.Team) (int, error) {
+	return query(ctx, db, func(st *state) (int, error) {
+		if _, ok := st.teamByName(team.Name); ok {
+			return 0, apperrors.ErrTeamExists
+		}
+
+		row := *team
+		row.ID = st.nextTeamID
+		st.nextTeamID++
+		st.teams[row.ID] = row
+
+		return row.ID, nil
+	})
+}
+
+func (r *TeamRepository) GetByID(ctx context.Context, db repository.DBTX, id int) (string, error) {
+	return query(ctx, db, func(st *state) (string, error) {
+		team, ok := st.teams[id]
+		if !ok {
+			return "", apperrors.ErrNotFound
+		}
+
+		return team.Name, nil
+	})
+}
+
+func (r *TeamRepository) GetByName(ctx context.Context, db repository.DBTX, name string) (*models.Team, error) {
+	return query(ctx, db, func(st *state) (*models.Team, error) {
+		team, ok := st.teamByName(name)
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return &team, nil
+	})
+}
+
+func (r *TeamRepository) GetByUserID(ctx context.Context, db repository.DBTX, userID string) (*models.Team, error) {
+	return query(ctx, db, func(st *state) (*models.Team, error) {
+		user, ok := st.users[userID]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		team, ok := st.teams[user.TeamID]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return &team, nil
+	})
+}
+
+func (r *TeamRepository) GetByUserIDs(
+	ctx context.Context,
+	db repository.DBTX,
+	userIDs []string,
+) (map[string]*models.Team, error) {
+	return query(ctx, db, func(st *state) (map[string]*models.Team, error) {
+		byID := make(map[int]*models.Team)
+		byUser := make(map[string]*models.Team, len(userIDs))
+		for _, userID := range userIDs {
+			user, ok := st.users[userID]
+			if !ok {
+				continue
+			}
+
+			if _, ok := byID[user.TeamID]; !ok {
+				team := st.teams[user.TeamID]
+				byID[user.TeamID] = &team
+			}
+			byUser[userID] = byID[user.TeamID]
+		}
+
+		return byUser, nil
+	})
+}
+
+func (r *TeamRepository) UpdateSettings(ctx context.Context, db repository.DBTX, team *models.Team) error {
+	return exec(ctx, db, func(st *state) error {
+		row, ok := st.teams[team.ID]
+		if !ok {
+			return apperrors.ErrNotFound
+		}
+
+		row.ReviewerStrategy = team.ReviewerStrategy
+		row.ReviewersRequired = team.ReviewersRequired
+		row.RequireApprovals = team.RequireApprovals
+		row.ChatWebhookURL = team.ChatWebhookURL
+		st.teams[team.ID] = row
+
+		return nil
+	})
+}
+
+func (r *TeamRepository) UpdateLastReviewer(ctx context.Context, db repository.DBTX, teamID int, reviewerID string) error {
+	return exec(ctx, db, func(st *state) error {
+		row, ok := st.teams[teamID]
+		if !ok {
+			return nil
+		}
+
+		row.LastReviewerID = &reviewerID
+		st.teams[teamID] = row
+
+		return nil
+	})
+}
+
+func (st *state) teamByName(name string) (models.Team, bool) {
+	for _, team := range st.teams {
+		if team.Name == name {
+			return team, true
+		}
+	}
+
+	return models.Team{}, false
+}
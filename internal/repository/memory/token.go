@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+type TokenRepository struct{}
+
+func (r *TokenRepository) Create(ctx context.Context, db repository.DBTX, token *models.APIToken) (int, error) {
+	return query(ctx, db, func(st *state) (int, error) {
+		row := *token
+		row.ID = st.nextTokenID
+		row.CreatedAt = now()
+		row.RevokedAt = nil
+		st.nextTokenID++
+		st.tokens[row.ID] = row
+
+		return row.ID, nil
+	})
+}
+
+func (r *TokenRepository) GetByID(ctx context.Context, db repository.DBTX, id int) (*models.APIToken, error) {
+	return query(ctx, db, func(st *state) (*models.APIToken, error) {
+		token, ok := st.tokens[id]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return &token, nil
+	})
+}
+
+func (r *TokenRepository) GetByHash(ctx context.Context, db repository.DBTX, hash string) (*models.APIToken, error) {
+	return query(ctx, db, func(st *state) (*models.APIToken, error) {
+		for _, token := range st.tokens {
+			if token.Hash == hash {
+				return &token, nil
+			}
+		}
+
+		return nil, apperrors.ErrNotFound
+	})
+}
+
+func (r *TokenRepository) List(ctx context.Context, db repository.DBTX) ([]models.APIToken, error) {
+	return query(ctx, db, func(st *state) ([]models.APIToken, error) {
+		var tokens []models.APIToken
+		for _, token := range st.tokens {
+			tokens = append(tokens, token)
+		}
+		sort.Slice(tokens, func(i, j int) bool {
+			return tokens[i].ID < tokens[j].ID
+		})
+
+		return tokens, nil
+	})
+}
+
+func (r *TokenRepository) Revoke(ctx context.Context, db repository.DBTX, id int) error {
+	return exec(ctx, db, func(st *state) error {
+		row, ok := st.tokens[id]
+		if !ok {
+			return apperrors.ErrNotFound
+		}
+
+		if row.RevokedAt == nil {
+			revokedAt := now()
+			row.RevokedAt = &revokedAt
+			st.tokens[id] = row
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,240 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+)
+
+type UserRepository struct{}
+
+func (r *UserRepository) Create(ctx context.Context, db repository.DBTX, teamID int, user *api.TeamMember) error {
+	return exec(ctx, db, func(st *state) error {
+		if _, ok := st.users[user.UserId]; ok {
+			return fmt.Errorf("user %q already exists", user.UserId)
+		}
+		if _, ok := st.teams[teamID]; !ok {
+			return fmt.Errorf("team %d does not exist", teamID)
+		}
+
+		st.users[user.UserId] = models.User{
+			ID:             user.UserId,
+			Name:           user.Username,
+			TeamID:         teamID,
+			IsActive:       user.IsActive,
+			MaxOpenReviews: reviewLimit(user.MaxOpenReviews),
+		}
+
+		return nil
+	})
+}
+
+func (r *UserRepository) Exists(ctx context.Context, db repository.DBTX, id string) (bool, error) {
+	return query(ctx, db, func(st *state) (bool, error) {
+		_, ok := st.users[id]
+		return ok, nil
+	})
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, db repository.DBTX, id string) (*models.User, error) {
+	return query(ctx, db, func(st *state) (*models.User, error) {
+		row, ok := st.users[id]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return &row, nil
+	})
+}
+
+func (r *UserRepository) GetByTeamID(ctx context.Context, db repository.DBTX, teamID int) ([]api.TeamMember, error) {
+	return query(ctx, db, func(st *state) ([]api.TeamMember, error) {
+		var members []api.TeamMember
+		for _, user := range st.sortedUsers() {
+			if user.TeamID == teamID {
+				members = append(members, toTeamMember(user))
+			}
+		}
+
+		return members, nil
+	})
+}
+
+func (r *UserRepository) GetActiveTeammates(ctx context.Context, db repository.DBTX, exceptID string) ([]api.TeamMember, error) {
+	return query(ctx, db, func(st *state) ([]api.TeamMember, error) {
+		author, ok := st.users[exceptID]
+		if !ok {
+			return nil, nil
+		}
+
+		at := now()
+		var teammates []api.TeamMember
+		for _, user := range st.sortedUsers() {
+			if user.TeamID == author.TeamID && user.ID != exceptID && user.IsActive && !st.absentAt(user.ID, at) {
+				teammates = append(teammates, toTeamMember(user))
+			}
+		}
+
+		return teammates, nil
+	})
+}
+
+func (r *UserRepository) Update(ctx context.Context, db repository.DBTX, teamID int, user *api.TeamMember) error {
+	return exec(ctx, db, func(st *state) error {
+		row, ok := st.users[user.UserId]
+		if !ok {
+			return apperrors.ErrNotFound
+		}
+
+		row.Name = user.Username
+		row.TeamID = teamID
+		row.IsActive = user.IsActive
+		row.MaxOpenReviews = reviewLimit(user.MaxOpenReviews)
+		st.users[user.UserId] = row
+
+		return nil
+	})
+}
+
+func (r *UserRepository) UpdateIsActive(ctx context.Context, db repository.DBTX, id string, active bool) (*models.User, error) {
+	return query(ctx, db, func(st *state) (*models.User, error) {
+		row, ok := st.users[id]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		row.IsActive = active
+		st.users[id] = row
+
+		return &row, nil
+	})
+}
+
+func (r *UserRepository) UpdateProfile(
+	ctx context.Context,
+	db repository.DBTX,
+	id string,
+	username *string,
+	maxOpenReviews *int,
+) (*models.User, error) {
+	return query(ctx, db, func(st *state) (*models.User, error) {
+		row, ok := st.users[id]
+		if !ok {
+			return nil, apperrors.ErrNotFound
+		}
+
+		if username != nil {
+			row.Name = *username
+		}
+		if maxOpenReviews != nil {
+			row.MaxOpenReviews = reviewLimit(maxOpenReviews)
+		}
+		st.users[id] = row
+
+		return &row, nil
+	})
+}
+
+func (r *UserRepository) DeactivateTeamMembers(
+	ctx context.Context,
+	db repository.DBTX,
+	teamID int,
+	userIDs []string,
+) ([]string, error) {
+	return query(ctx, db, func(st *state) ([]string, error) {
+		var selected map[string]struct{}
+		if userIDs != nil {
+			selected = make(map[string]struct{}, len(userIDs))
+			for _, id := range userIDs {
+				selected[id] = struct{}{}
+			}
+		}
+
+		var deactivated []string
+		for _, user := range st.sortedUsers() {
+			if user.TeamID != teamID {
+				continue
+			}
+			if _, ok := selected[user.ID]; selected != nil && !ok {
+				continue
+			}
+
+			user.IsActive = false
+			st.users[user.ID] = user
+			deactivated = append(deactivated, user.ID)
+		}
+
+		return deactivated, nil
+	})
+}
+
+func (r *UserRepository) GetActiveByTeamIDs(
+	ctx context.Context,
+	db repository.DBTX,
+	teamIDs []int,
+) (map[int][]models.User, error) {
+	return query(ctx, db, func(st *state) (map[int][]models.User, error) {
+		teams := make(map[int]struct{}, len(teamIDs))
+		for _, id := range teamIDs {
+			teams[id] = struct{}{}
+		}
+
+		at := now()
+		members := make(map[int][]models.User, len(teamIDs))
+		for _, user := range st.sortedUsers() {
+			if _, ok := teams[user.TeamID]; ok && user.IsActive && !st.absentAt(user.ID, at) {
+				members[user.TeamID] = append(members[user.TeamID], user)
+			}
+		}
+
+		return members, nil
+	})
+}
+
+func (st *state) sortedUsers() []models.User {
+	users := make([]models.User, 0, len(st.users))
+	for _, user := range st.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+
+	return users
+}
+
+// absentAt сообщает, идёт ли у пользователя отсутствие в момент at.
+func (st *state) absentAt(userID string, at time.Time) bool {
+	for _, absence := range st.absences {
+		if absence.UserID == userID && !absence.StartsAt.After(at) && absence.EndsAt.After(at) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toTeamMember(user models.User) api.TeamMember {
+	return api.TeamMember{
+		UserId:         user.ID,
+		Username:       user.Name,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: user.MaxOpenReviews,
+	}
+}
+
+// reviewLimit переводит лимит из API в хранимое значение: 0 и nil означают «без ограничения».
+func reviewLimit(maxOpenReviews *int) *int {
+	if maxOpenReviews == nil || *maxOpenReviews <= 0 {
+		return nil
+	}
+
+	limit := *maxOpenReviews
+	return &limit
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type AbsenceRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newAbsenceRepository() *AbsenceRepository {
+	return &AbsenceRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *AbsenceRepository) Create(ctx context.Context, db repository.DBTX, absence *models.Absence) (int, error) {
+	sql, args, err := r.builder.
+		Insert("user_absences").
+		Columns("user_id", "starts_at", "ends_at", "reason").
+		Values(absence.UserID, absence.StartsAt, absence.EndsAt, absence.Reason).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	var id int
+	if err = querier(db).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("execute query: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *AbsenceRepository) GetByUserID(ctx context.Context, db repository.DBTX, userID string) ([]models.Absence, error) {
+	sql, args, err := r.builder.
+		Select("id", "user_id", "starts_at", "ends_at", "reason").
+		From("user_absences").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("starts_at").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var absences []models.Absence
+	for rows.Next() {
+		var absence models.Absence
+		err := rows.Scan(&absence.ID, &absence.UserID, &absence.StartsAt, &absence.EndsAt, &absence.Reason)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		absences = append(absences, absence)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return absences, nil
+}
+
+func (r *AbsenceRepository) Delete(ctx context.Context, db repository.DBTX, id int) error {
+	sql, args, err := r.builder.
+		Delete("user_absences").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build delete: %w", err)
+	}
+
+	tag, err := querier(db).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("delete absence: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// notAbsentNow отсекает пользователей, у которых прямо сейчас идёт отсутствие.
+// Отсутствие заканчивается само по ends_at, повторно включать пользователя не нужно.
+func notAbsentNow(userIDColumn string) squirrel.Sqlizer {
+	return squirrel.Expr(
+		"NOT EXISTS (SELECT 1 FROM user_absences a WHERE a.user_id = " + userIDColumn +
+			" AND a.starts_at <= NOW() AND a.ends_at > NOW())",
+	)
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type EventDeliveryRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newEventDeliveryRepository() *EventDeliveryRepository {
+	return &EventDeliveryRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *EventDeliveryRepository) CreateBatch(ctx context.Context, db repository.DBTX, deliveries []models.EventDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	query := r.builder.
+		Insert("event_deliveries").
+		Columns("subscription_id", "event_id")
+	for _, delivery := range deliveries {
+		query = query.Values(delivery.SubscriptionID, delivery.EventID)
+	}
+
+	sql, args, err := query.
+		Suffix("ON CONFLICT (subscription_id, event_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err = querier(db).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("insert event deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDue пропускает доставки неактивных подписок: они дождутся, пока подписку включат снова.
+func (r *EventDeliveryRepository) ClaimDue(
+	ctx context.Context,
+	db repository.DBTX,
+	lease time.Duration,
+	limit int,
+) ([]models.PendingDelivery, error) {
+	sql, args, err := r.builder.
+		Select("d.id", "d.attempts", "s.url", "s.secret", "o.id", "o.event_type", "o.payload", "o.created_at").
+		From("event_deliveries d").
+		Join("outbox o ON o.id = d.event_id").
+		Join("webhook_subscriptions s ON s.id = d.subscription_id").
+		Where(squirrel.Eq{"d.status": models.DeliveryPending, "s.is_active": true}).
+		Where("d.next_attempt_at <= NOW()").
+		OrderBy("d.next_attempt_at", "d.id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE OF d SKIP LOCKED").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		claimed []models.PendingDelivery
+		ids     []int64
+	)
+	for rows.Next() {
+		var d models.PendingDelivery
+		err := rows.Scan(&d.ID, &d.Attempts, &d.URL, &d.Secret, &d.Event.ID, &d.Event.Type, &d.Event.Payload, &d.Event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		claimed = append(claimed, d)
+		ids = append(ids, d.ID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	sql, args, err = r.builder.
+		Update("event_deliveries").
+		Set("next_attempt_at", squirrel.Expr("NOW() + make_interval(secs => ?)", lease.Seconds())).
+		Where(squirrel.Eq{"id": ids}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build update: %w", err)
+	}
+
+	if _, err = querier(db).Exec(ctx, sql, args...); err != nil {
+		return nil, fmt.Errorf("claim event deliveries: %w", err)
+	}
+
+	return claimed, nil
+}
+
+func (r *EventDeliveryRepository) SaveAttempt(
+	ctx context.Context,
+	db repository.DBTX,
+	id int64,
+	attempt *models.DeliveryAttempt,
+) error {
+	query := r.builder.
+		Update("event_deliveries").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("status", attempt.Status).
+		Set("last_status_code", attempt.StatusCode).
+		Set("last_error", attempt.Error).
+		Where(squirrel.Eq{"id": id})
+
+	switch attempt.Status {
+	case models.DeliveryDelivered:
+		query = query.Set("delivered_at", squirrel.Expr("NOW()"))
+	case models.DeliveryPending:
+		query = query.Set("next_attempt_at", squirrel.Expr("NOW() + make_interval(secs => ?)", attempt.RetryIn.Seconds()))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("build update: %w", err)
+	}
+
+	if _, err = querier(db).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("save delivery attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (r *EventDeliveryRepository) GetBySubscription(
+	ctx context.Context,
+	db repository.DBTX,
+	subscriptionID int,
+	limit int,
+) ([]models.EventDelivery, error) {
+	sql, args, err := r.builder.
+		Select(
+			"d.id", "d.subscription_id", "d.event_id", "o.event_type", "d.status", "d.attempts",
+			"d.next_attempt_at", "d.last_status_code", "d.last_error", "d.created_at", "d.delivered_at",
+		).
+		From("event_deliveries d").
+		Join("outbox o ON o.id = d.event_id").
+		Where(squirrel.Eq{"d.subscription_id": subscriptionID}).
+		OrderBy("d.id DESC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.EventDelivery
+	for rows.Next() {
+		var d models.EventDelivery
+		err := rows.Scan(
+			&d.ID,
+			&d.SubscriptionID,
+			&d.EventID,
+			&d.EventType,
+			&d.Status,
+			&d.Attempts,
+			&d.NextAttemptAt,
+			&d.LastStatusCode,
+			&d.LastError,
+			&d.CreatedAt,
+			&d.DeliveredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Querier — общее подмножество pgxpool.Pool и pgx.Tx, через которое работают репозитории.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// querier приводит соединение, полученное сервисом от database.Database, к Querier.
+// Каждый запрос получает span с текстом SQL. На соединение другого хранилища все запросы
+// возвращают ErrForeignConn.
+func querier(db repository.DBTX) Querier {
+	q, ok := db.(Querier)
+	if !ok {
+		return failedQuerier{repository.ForeignConnError(config.StoragePostgres, db)}
+	}
+
+	return tracedQuerier{q}
+}
+
+// failedQuerier отвечает ошибкой на любой запрос.
+type failedQuerier struct {
+	err error
+}
+
+func (q failedQuerier) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, q.err
+}
+
+func (q failedQuerier) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, q.err
+}
+
+func (q failedQuerier) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	return failedRow(q)
+}
+
+type failedRow struct {
+	err error
+}
+
+func (r failedRow) Scan(...any) error {
+	return r.err
+}
+
+type tracedQuerier struct {
+	Querier
+}
+
+func (q tracedQuerier) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, span := tracing.StartQuery(ctx, tracing.DBSystemPostgres, sql)
+	tag, err := q.Querier.Exec(ctx, sql, args...)
+	tracing.End(span, err)
+
+	return tag, err
+}
+
+func (q tracedQuerier) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, span := tracing.StartQuery(ctx, tracing.DBSystemPostgres, sql)
+	rows, err := q.Querier.Query(ctx, sql, args...)
+	tracing.End(span, err)
+
+	return rows, err
+}
+
+// QueryRow: ошибка станет известна только в Scan, span отражает время выполнения запроса.
+func (q tracedQuerier) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, span := tracing.StartQuery(ctx, tracing.DBSystemPostgres, sql)
+	row := q.Querier.QueryRow(ctx, sql, args...)
+	span.End()
+
+	return row
+}
+
+func NewRepository() *repository.Repository {
+	return &repository.Repository{
+		TeamRepository:          newTeamRepository(),
+		UserRepository:          newUserRepository(),
+		ReviewRepository:        newReviewRepository(),
+		PullRequestRepository:   newPullRequestRepository(),
+		StatsRepository:         newStatsRepository(),
+		AbsenceRepository:       newAbsenceRepository(),
+		LoginRepository:         newLoginRepository(),
+		DeliveryRepository:      newDeliveryRepository(),
+		SubscriptionRepository:  newSubscriptionRepository(),
+		OutboxRepository:        newOutboxRepository(),
+		EventDeliveryRepository: newEventDeliveryRepository(),
+		TokenRepository:         newTokenRepository(),
+		AuditRepository:         newAuditRepository(),
+	}
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+// foreignConn — соединение чужого хранилища.
+type foreignConn struct{}
+
+func (foreignConn) StorageName() string {
+	return config.StorageMemory
+}
+
+func TestForeignConn(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository()
+
+	tests := []struct {
+		name string
+		call func(db repository.DBTX) error
+	}{
+		{
+			name: "query row",
+			call: func(db repository.DBTX) error {
+				_, err := repo.UserRepository.GetByID(ctx, db, "u1")
+				return err
+			},
+		},
+		{
+			name: "query",
+			call: func(db repository.DBTX) error {
+				_, err := repo.UserRepository.GetByTeamID(ctx, db, 1)
+				return err
+			},
+		},
+		{
+			name: "exec",
+			call: func(db repository.DBTX) error {
+				return repo.AuditRepository.Add(ctx, db, &models.AuditEntry{Action: models.AuditTeamCreated})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, db := range []repository.DBTX{foreignConn{}, nil} {
+				if err := tt.call(db); !errors.Is(err, repository.ErrForeignConn) {
+					t.Errorf("error with %T connection = %v, want ErrForeignConn", db, err)
+				}
+			}
+		})
+	}
+}
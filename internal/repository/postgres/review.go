@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type ReviewRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newReviewRepository() *ReviewRepository {
+	return &ReviewRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ReviewRepository) Assign(ctx context.Context, db repository.DBTX, prID string, reviewerIDs ...string) error {
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	query := r.builder.
+		Insert("reviewers").
+		Columns("pull_request_id", "reviewer_id")
+	for _, reviewerID := range reviewerIDs {
+		query = query.Values(prID, reviewerID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	_, err = querier(db).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("insert new reviewer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) GetReviewersByPR(ctx context.Context, db repository.DBTX, prID string) ([]string, error) {
+	sql, args, err := r.builder.
+		Select("reviewer_id").
+		From("reviewers").
+		Where(squirrel.Eq{"pull_request_id": prID}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build reviewers query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var reviewerIDs []string
+	for rows.Next() {
+		var reviewerID string
+		if err := rows.Scan(&reviewerID); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		reviewerIDs = append(reviewerIDs, reviewerID)
+	}
+
+	return reviewerIDs, nil
+}
+
+// GetByPR возвращает назначенных ревьюверов PR вместе с состоянием их ревью.
+func (r *ReviewRepository) GetByPR(ctx context.Context, db repository.DBTX, prID string) ([]models.Review, error) {
+	sql, args, err := r.builder.
+		Select("reviewer_id", "state", "comment", "reviewed_at").
+		From("reviewers").
+		Where(squirrel.Eq{"pull_request_id": prID}).
+		OrderBy("reviewer_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build reviews query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []models.Review
+	for rows.Next() {
+		review := models.Review{PullRequestID: prID}
+		if err := rows.Scan(&review.ReviewerID, &review.State, &review.Comment, &review.ReviewedAt); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *ReviewRepository) UpdateState(ctx context.Context, db repository.DBTX, review *models.Review) error {
+	sql, args, err := r.builder.
+		Update("reviewers").
+		Set("state", review.State).
+		Set("comment", review.Comment).
+		Set("reviewed_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"pull_request_id": review.PullRequestID, "reviewer_id": review.ReviewerID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build update: %w", err)
+	}
+
+	tag, err := querier(db).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("update review state: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrNotAssigned
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) Delete(ctx context.Context, db repository.DBTX, prID, oldReviewerID string) error {
+	sql, args, err := r.builder.
+		Delete("reviewers").
+		Where(squirrel.Eq{"pull_request_id": prID, "reviewer_id": oldReviewerID}).
+		ToSql()
+	
+	if err != nil {
+		return fmt.Errorf("build delete: %w", err)
+	}
+
+	tag, err := querier(db).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("delete old reviewer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) CountOpenByReviewers(ctx context.Context, db repository.DBTX, reviewerIDs []string) (map[string]int, error) {
+	sql, args, err := r.builder.
+		Select("r.reviewer_id", "COUNT(*)").
+		From("reviewers r").
+		Join("pull_requests pr ON pr.id = r.pull_request_id").
+		Where(squirrel.Eq{"r.reviewer_id": reviewerIDs, "pr.status": models.StatusOpen}).
+		GroupBy("r.reviewer_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build open reviews query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	openReviews := make(map[string]int, len(reviewerIDs))
+	for rows.Next() {
+		var (
+			reviewerID string
+			count      int
+		)
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		openReviews[reviewerID] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return openReviews, nil
+}
+
+func (r *ReviewRepository) AssignBatch(ctx context.Context, db repository.DBTX, reviews []models.Review) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	query := r.builder.
+		Insert("reviewers").
+		Columns("pull_request_id", "reviewer_id")
+	for _, review := range reviews {
+		query = query.Values(review.PullRequestID, review.ReviewerID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err = querier(db).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("insert reviewers: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) DeleteByReviewers(ctx context.Context, db repository.DBTX, prIDs, reviewerIDs []string) error {
+	sql, args, err := r.builder.
+		Delete("reviewers").
+		Where(squirrel.Eq{"pull_request_id": prIDs, "reviewer_id": reviewerIDs}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build delete: %w", err)
+	}
+
+	if _, err = querier(db).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("delete reviewers: %w", err)
+	}
+
+	return nil
+}
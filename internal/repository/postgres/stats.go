@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type StatsRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newStatsRepository() *StatsRepository {
+	return &StatsRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *StatsRepository) GetAssignments(
+	ctx context.Context,
+	db repository.DBTX,
+	filter models.StatsFilter,
+) ([]models.UserAssignmentStats, error) {
+	prJoin, joinArgs := periodJoin("pull_requests pr ON pr.id = r.pull_request_id", filter)
+
+	query := r.builder.
+		Select(
+			"u.id",
+			"u.name",
+			"t.name",
+			"COUNT(pr.id)",
+			"COUNT(pr.id) FILTER (WHERE pr.status = 'OPEN')",
+			"COUNT(pr.id) FILTER (WHERE pr.status = 'MERGED')",
+		).
+		From("users u").
+		Join("teams t ON t.id = u.team_id").
+		LeftJoin("reviewers r ON r.reviewer_id = u.id").
+		LeftJoin(prJoin, joinArgs...).
+		GroupBy("u.id", "u.name", "t.name").
+		OrderBy("u.id")
+	if filter.TeamName != nil {
+		query = query.Where(squirrel.Eq{"t.name": *filter.TeamName})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.UserAssignmentStats
+	for rows.Next() {
+		var s models.UserAssignmentStats
+		if err := rows.Scan(&s.UserID, &s.Username, &s.TeamName, &s.Total, &s.Open, &s.Merged); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepository) GetPullRequests(
+	ctx context.Context,
+	db repository.DBTX,
+	filter models.StatsFilter,
+) ([]models.TeamPullRequestStats, error) {
+	prJoin, joinArgs := periodJoin("pull_requests pr ON pr.author_id = u.id", filter)
+
+	query := r.builder.
+		Select(
+			"t.name",
+			"COUNT(pr.id) FILTER (WHERE pr.status = 'OPEN')",
+			"COUNT(pr.id) FILTER (WHERE pr.status = 'MERGED')",
+			"COUNT(pr.id) FILTER (WHERE NOT EXISTS (SELECT 1 FROM reviewers r WHERE r.pull_request_id = pr.id))",
+			"AVG(EXTRACT(EPOCH FROM pr.merged_at - pr.created_at))::float8",
+		).
+		From("teams t").
+		LeftJoin("users u ON u.team_id = t.id").
+		LeftJoin(prJoin, joinArgs...).
+		GroupBy("t.name").
+		OrderBy("t.name")
+	if filter.TeamName != nil {
+		query = query.Where(squirrel.Eq{"t.name": *filter.TeamName})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.TeamPullRequestStats
+	for rows.Next() {
+		var s models.TeamPullRequestStats
+		if err := rows.Scan(&s.TeamName, &s.Open, &s.Merged, &s.WithoutReviewers, &s.AvgTimeToMerge); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		stats = append(stats, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return stats, nil
+}
+
+// periodJoin добавляет окно по created_at в условие LEFT JOIN, а не в WHERE,
+// чтобы пользователи и команды без PR за период тоже попадали в статистику с нулями.
+func periodJoin(join string, filter models.StatsFilter) (string, []interface{}) {
+	var args []interface{}
+	if filter.From != nil {
+		join += " AND pr.created_at >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		join += " AND pr.created_at < ?"
+		args = append(args, *filter.To)
+	}
+
+	return join, args
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type TokenRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newTokenRepository() *TokenRepository {
+	return &TokenRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *TokenRepository) Create(ctx context.Context, db repository.DBTX, token *models.APIToken) (int, error) {
+	sql, args, err := r.builder.
+		Insert("api_tokens").
+		Columns("name", "role", "token_hash").
+		Values(token.Name, token.Role, token.Hash).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	var id int
+	if err = querier(db).QueryRow(ctx, sql, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("execute query: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *TokenRepository) GetByID(ctx context.Context, db repository.DBTX, id int) (*models.APIToken, error) {
+	return r.getOne(ctx, db, squirrel.Eq{"id": id})
+}
+
+func (r *TokenRepository) GetByHash(ctx context.Context, db repository.DBTX, hash string) (*models.APIToken, error) {
+	return r.getOne(ctx, db, squirrel.Eq{"token_hash": hash})
+}
+
+func (r *TokenRepository) List(ctx context.Context, db repository.DBTX) ([]models.APIToken, error) {
+	return r.get(ctx, db, nil)
+}
+
+func (r *TokenRepository) Revoke(ctx context.Context, db repository.DBTX, id int) error {
+	sql, args, err := r.builder.
+		Update("api_tokens").
+		Set("revoked_at", squirrel.Expr("COALESCE(revoked_at, NOW())")).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	tag, err := querier(db).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *TokenRepository) getOne(ctx context.Context, db repository.DBTX, where squirrel.Sqlizer) (*models.APIToken, error) {
+	tokens, err := r.get(ctx, db, where)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, apperrors.ErrNotFound
+	}
+
+	return &tokens[0], nil
+}
+
+func (r *TokenRepository) get(ctx context.Context, db repository.DBTX, where squirrel.Sqlizer) ([]models.APIToken, error) {
+	query := r.builder.
+		Select("id", "name", "role", "token_hash", "created_at", "revoked_at").
+		From("api_tokens").
+		OrderBy("id")
+	if where != nil {
+		query = query.Where(where)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []models.APIToken
+	for rows.Next() {
+		var token models.APIToken
+		err := rows.Scan(&token.ID, &token.Name, &token.Role, &token.Hash, &token.CreatedAt, &token.RevokedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/Masterminds/squirrel"
+)
+
+type UserRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newUserRepository() *UserRepository {
+	return &UserRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *UserRepository) Create(ctx context.Context, db repository.DBTX, teamID int, user *api.TeamMember) error {
+	sql, args, err := r.builder.
+		Insert("users").
+		Columns("id", "name", "is_active", "team_id", "max_open_reviews").
+		Values(user.UserId, user.Username, user.IsActive, teamID, reviewLimit(user.MaxOpenReviews)).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err = querier(db).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Exists(ctx context.Context, db repository.DBTX, id string) (bool, error) {
+	sql, args, err := r.builder.
+		Select("1").
+		From("users").
+		Where(squirrel.Eq{"id": id}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+    var exists bool
+    err = querier(db).QueryRow(ctx, sql, args...).Scan(&exists)
+    if err != nil {
+        if errors.Is(err, pgx.ErrNoRows) {
+            return false, nil
+        }
+        return false, fmt.Errorf("query row: %w", err)
+    }
+
+    return exists, nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, db repository.DBTX, id string) (*models.User, error) {
+	sql, args, err := r.builder.
+		Select("id", "name", "is_active", "team_id", "max_open_reviews").
+		From("users").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return scanUser(querier(db).QueryRow(ctx, sql, args...))
+}
+
+func (r *UserRepository) GetByTeamID(ctx context.Context, db repository.DBTX, teamID int) ([]api.TeamMember, error) {
+	sql, args, err := r.builder.
+		Select("id", "name", "is_active", "max_open_reviews").
+		From("users").
+		Where(squirrel.Eq{"team_id": teamID}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var teamMembers []api.TeamMember
+	for rows.Next() {
+		var member api.TeamMember
+		err := rows.Scan(&member.UserId, &member.Username, &member.IsActive, &member.MaxOpenReviews)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		teamMembers = append(teamMembers, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return teamMembers, nil
+}
+
+func (r *UserRepository) GetActiveTeammates(ctx context.Context, db repository.DBTX, exceptID string) ([]api.TeamMember, error) {
+	sql, args, err := r.builder.
+		Select("u1.id", "u1.name", "u1.is_active", "u1.max_open_reviews").
+		From("users u1").
+		Join("users u2 ON u1.team_id = u2.team_id").
+		Where(squirrel.Eq{"u2.id": exceptID, "u1.is_active": true}).
+		Where(squirrel.NotEq{"u1.id": exceptID}).
+		Where(notAbsentNow("u1.id")).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var teammates []api.TeamMember
+	for rows.Next() {
+		var member api.TeamMember
+		err := rows.Scan(&member.UserId, &member.Username, &member.IsActive, &member.MaxOpenReviews)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		teammates = append(teammates, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return teammates, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, db repository.DBTX, teamID int, user *api.TeamMember) error {
+	sql, args, err := r.builder.
+		Update("users").
+		Set("name", user.Username).
+		Set("team_id", teamID).
+		Set("is_active", user.IsActive).
+		Set("max_open_reviews", reviewLimit(user.MaxOpenReviews)).
+		Where(squirrel.Eq{"id": user.UserId}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	cmdTag, err := querier(db).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("execute query: %w", err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdateIsActive(ctx context.Context, db repository.DBTX, id string, active bool) (*models.User, error) {
+	sql, args, err := r.builder.
+		Update("users").
+		Set("is_active", active).
+		Where(squirrel.Eq{"id": id}).
+		Suffix(userReturning).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return scanUser(querier(db).QueryRow(ctx, sql, args...))
+}
+
+// UpdateProfile меняет только переданные поля. maxOpenReviews == 0 снимает ограничение.
+func (r *UserRepository) UpdateProfile(
+	ctx context.Context,
+	db repository.DBTX,
+	id string,
+	username *string,
+	maxOpenReviews *int,
+) (*models.User, error) {
+	query := r.builder.
+		Update("users").
+		Where(squirrel.Eq{"id": id}).
+		Suffix(userReturning)
+	if username != nil {
+		query = query.Set("name", *username)
+	}
+	if maxOpenReviews != nil {
+		query = query.Set("max_open_reviews", reviewLimit(maxOpenReviews))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return scanUser(querier(db).QueryRow(ctx, sql, args...))
+}
+
+const userReturning = "RETURNING id, name, is_active, team_id, max_open_reviews"
+
+func scanUser(row pgx.Row) (*models.User, error) {
+	user := &models.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.IsActive,
+		&user.TeamID,
+		&user.MaxOpenReviews,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("execute query and scan result: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) DeactivateTeamMembers(ctx context.Context, db repository.DBTX, teamID int, userIDs []string) ([]string, error) {
+	query := r.builder.
+		Update("users").
+		Set("is_active", false).
+		Where(squirrel.Eq{"team_id": teamID}).
+		Suffix("RETURNING id")
+	if userIDs != nil {
+		query = query.Where(squirrel.Eq{"id": userIDs})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var deactivated []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		deactivated = append(deactivated, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return deactivated, nil
+}
+
+func (r *UserRepository) GetActiveByTeamIDs(ctx context.Context, db repository.DBTX, teamIDs []int) (map[int][]models.User, error) {
+	sql, args, err := r.builder.
+		Select("team_id", "id", "name", "max_open_reviews").
+		From("users").
+		Where(squirrel.Eq{"team_id": teamIDs, "is_active": true}).
+		Where(notAbsentNow("users.id")).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	members := make(map[int][]models.User, len(teamIDs))
+	for rows.Next() {
+		user := models.User{IsActive: true}
+		if err := rows.Scan(&user.TeamID, &user.ID, &user.Name, &user.MaxOpenReviews); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		members[user.TeamID] = append(members[user.TeamID], user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return members, nil
+}
+
+// reviewLimit переводит лимит из API в значение колонки: 0 и nil означают «без ограничения».
+func reviewLimit(maxOpenReviews *int) *int {
+	if maxOpenReviews == nil || *maxOpenReviews <= 0 {
+		return nil
+	}
+
+	return maxOpenReviews
+}
@@ -2,29 +2,179 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
 )
 
+// DBTX — соединение или транзакция хранилища, полученные из database.Storage (Conn или BeginTx).
+// Каждая реализация репозиториев принимает только соединения своего хранилища, на чужое
+// отвечает ошибкой ErrForeignConn.
+//
+// Тип соединения проверяется при выполнении, а не компилятором, сознательно: сервисы написаны
+// один раз для всех хранилищ через database.Storage, а хранилище и его репозитории создаются
+// вместе в openStorage (cmd/app). Параметр типа соединения пришлось бы протащить через все сервисы
+// ради ошибки, которую исключает это единственное место сборки; проверку покрывают тесты репозиториев.
 type DBTX interface {
-	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
-	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
-	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	StorageName() string
 }
 
-type Repository struct {
-	TeamRepository *TeamRepository
-	UserRepository *UserRepository
-	ReviewRepository *ReviewRepository
-	PullRequestRepository *PullRequestRepository
-}
-
-func NewRepository() *Repository {
-	return &Repository{
-		TeamRepository: newTeamRepository(),
-		UserRepository: newUserRepository(),
-		ReviewRepository: newReviewRepository(),
-		PullRequestRepository: newPullRequestRepository(),
+// ErrForeignConn — репозиторий получил соединение другого хранилища. Это ошибка в коде сервиса,
+// а не в данных, поэтому клиент видит её как 500.
+var ErrForeignConn = errors.New("connection belongs to another storage")
+
+// ForeignConnError описывает, какое соединение получил репозиторий хранилища storage.
+func ForeignConnError(storage string, db DBTX) error {
+	if db == nil {
+		return fmt.Errorf("%w: %s repository got nil connection", ErrForeignConn, storage)
 	}
+
+	return fmt.Errorf("%w: %s repository got %s connection %T", ErrForeignConn, storage, db.StorageName(), db)
+}
+
+type TeamRepository interface {
+	Create(ctx context.Context, db DBTX, team *models.Team) (int, error)
+	GetByID(ctx context.Context, db DBTX, id int) (string, error)
+	GetByName(ctx context.Context, db DBTX, name string) (*models.Team, error)
+	GetByUserID(ctx context.Context, db DBTX, userID string) (*models.Team, error)
+	// GetByUserIDs возвращает команду каждого из пользователей; участники одной команды
+	// получают один и тот же *models.Team.
+	GetByUserIDs(ctx context.Context, db DBTX, userIDs []string) (map[string]*models.Team, error)
+	UpdateSettings(ctx context.Context, db DBTX, team *models.Team) error
+	UpdateLastReviewer(ctx context.Context, db DBTX, teamID int, reviewerID string) error
+}
+
+type UserRepository interface {
+	Create(ctx context.Context, db DBTX, teamID int, user *api.TeamMember) error
+	Exists(ctx context.Context, db DBTX, id string) (bool, error)
+	GetByID(ctx context.Context, db DBTX, id string) (*models.User, error)
+	GetByTeamID(ctx context.Context, db DBTX, teamID int) ([]api.TeamMember, error)
+	// GetActiveTeammates возвращает активных и не отсутствующих сейчас участников команды exceptID, кроме него самого.
+	GetActiveTeammates(ctx context.Context, db DBTX, exceptID string) ([]api.TeamMember, error)
+	Update(ctx context.Context, db DBTX, teamID int, user *api.TeamMember) error
+	UpdateIsActive(ctx context.Context, db DBTX, id string, active bool) (*models.User, error)
+	// UpdateProfile меняет только переданные поля. maxOpenReviews == 0 снимает ограничение.
+	UpdateProfile(ctx context.Context, db DBTX, id string, username *string, maxOpenReviews *int) (*models.User, error)
+	// DeactivateTeamMembers деактивирует userIDs (всех участников, если nil) и возвращает их id.
+	DeactivateTeamMembers(ctx context.Context, db DBTX, teamID int, userIDs []string) ([]string, error)
+	GetActiveByTeamIDs(ctx context.Context, db DBTX, teamIDs []int) (map[int][]models.User, error)
+}
+
+type ReviewRepository interface {
+	Assign(ctx context.Context, db DBTX, prID string, reviewerIDs ...string) error
+	GetReviewersByPR(ctx context.Context, db DBTX, prID string) ([]string, error)
+	// GetByPR возвращает назначенных ревьюверов PR вместе с состоянием их ревью.
+	GetByPR(ctx context.Context, db DBTX, prID string) ([]models.Review, error)
+	UpdateState(ctx context.Context, db DBTX, review *models.Review) error
+	Delete(ctx context.Context, db DBTX, prID, oldReviewerID string) error
+	CountOpenByReviewers(ctx context.Context, db DBTX, reviewerIDs []string) (map[string]int, error)
+	AssignBatch(ctx context.Context, db DBTX, reviews []models.Review) error
+	DeleteByReviewers(ctx context.Context, db DBTX, prIDs, reviewerIDs []string) error
+}
+
+type PullRequestRepository interface {
+	Create(ctx context.Context, db DBTX, pr *models.PullRequest) error
+	GetByID(ctx context.Context, db DBTX, id string) (*models.PullRequest, error)
+	Exists(ctx context.Context, db DBTX, id string) (bool, error)
+	// UpdateMergeStatus, UpdateCloseStatus и Reopen меняют статус только из допустимого исходного,
+	// иначе ничего не делают.
+	UpdateMergeStatus(ctx context.Context, db DBTX, id string) error
+	UpdateCloseStatus(ctx context.Context, db DBTX, id string) error
+	Reopen(ctx context.Context, db DBTX, id string) error
+	GetAssignedForUser(ctx context.Context, db DBTX, userID string) ([]models.PullRequest, error)
+	// GetOpenReviewedBy возвращает открытые PR, где ревьювером назначен кто-то из reviewerIDs,
+	// вместе с полным списком их текущих ревьюверов.
+	GetOpenReviewedBy(ctx context.Context, db DBTX, reviewerIDs []string) ([]models.PullRequest, error)
+}
+
+type StatsRepository interface {
+	GetAssignments(ctx context.Context, db DBTX, filter models.StatsFilter) ([]models.UserAssignmentStats, error)
+	GetPullRequests(ctx context.Context, db DBTX, filter models.StatsFilter) ([]models.TeamPullRequestStats, error)
+}
+
+type AbsenceRepository interface {
+	Create(ctx context.Context, db DBTX, absence *models.Absence) (int, error)
+	GetByUserID(ctx context.Context, db DBTX, userID string) ([]models.Absence, error)
+	Delete(ctx context.Context, db DBTX, id int) error
+}
+
+type LoginRepository interface {
+	// Link привязывает логин к пользователю, заменяя прежнюю привязку этого логина.
+	Link(ctx context.Context, db DBTX, login *models.UserLogin) error
+	// Unlink удаляет привязку и возвращает её, ErrNotFound если привязки не было.
+	Unlink(ctx context.Context, db DBTX, provider models.Provider, login string) (*models.UserLogin, error)
+	// Resolve возвращает user_id по логину, ErrNotFound если логин не привязан.
+	Resolve(ctx context.Context, db DBTX, provider models.Provider, login string) (string, error)
+}
+
+type DeliveryRepository interface {
+	Exists(ctx context.Context, db DBTX, provider models.Provider, deliveryID string) (bool, error)
+	// Save запоминает доставку и возвращает false, если она уже сохранена; повторное сохранение ничего не меняет.
+	// Внутри транзакции вторая вставка того же id ждёт завершения первой, поэтому Save служит атомарным захватом доставки.
+	Save(ctx context.Context, db DBTX, provider models.Provider, deliveryID string, action models.WebhookAction) (bool, error)
+}
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, db DBTX, sub *models.Subscription) (int, error)
+	GetByID(ctx context.Context, db DBTX, id int) (*models.Subscription, error)
+	List(ctx context.Context, db DBTX) ([]models.Subscription, error)
+	// Update заменяет url, список событий и is_active подписки, ErrNotFound если её нет.
+	Update(ctx context.Context, db DBTX, sub *models.Subscription) error
+	Delete(ctx context.Context, db DBTX, id int) error
+	// GetActiveIDsByEvent возвращает id активных подписок на события типа eventType.
+	GetActiveIDsByEvent(ctx context.Context, db DBTX, eventType models.EventType) ([]int, error)
+}
+
+type OutboxRepository interface {
+	Add(ctx context.Context, db DBTX, eventType models.EventType, payload []byte) error
+	// GetUndispatched возвращает до limit ещё не разосланных событий в порядке записи.
+	GetUndispatched(ctx context.Context, db DBTX, limit int) ([]models.Event, error)
+	MarkDispatched(ctx context.Context, db DBTX, ids []int64) error
+}
+
+type EventDeliveryRepository interface {
+	CreateBatch(ctx context.Context, db DBTX, deliveries []models.EventDelivery) error
+	// ClaimDue берёт до limit доставок, время попытки которых уже подошло, и откладывает их
+	// следующую попытку на lease, чтобы другой экземпляр сервиса не отправил их одновременно.
+	ClaimDue(ctx context.Context, db DBTX, lease time.Duration, limit int) ([]models.PendingDelivery, error)
+	// SaveAttempt увеличивает счётчик попыток и записывает результат попытки.
+	SaveAttempt(ctx context.Context, db DBTX, id int64, attempt *models.DeliveryAttempt) error
+	// GetBySubscription возвращает до limit последних доставок подписки, новые первыми.
+	GetBySubscription(ctx context.Context, db DBTX, subscriptionID int, limit int) ([]models.EventDelivery, error)
+}
+
+type TokenRepository interface {
+	Create(ctx context.Context, db DBTX, token *models.APIToken) (int, error)
+	GetByID(ctx context.Context, db DBTX, id int) (*models.APIToken, error)
+	// GetByHash ищет токен по хешу, в том числе отозванный; ErrNotFound если такого нет.
+	GetByHash(ctx context.Context, db DBTX, hash string) (*models.APIToken, error)
+	List(ctx context.Context, db DBTX) ([]models.APIToken, error)
+	// Revoke отзывает токен; для уже отозванного ничего не меняет.
+	Revoke(ctx context.Context, db DBTX, id int) error
+}
+
+type AuditRepository interface {
+	Add(ctx context.Context, db DBTX, entry *models.AuditEntry) error
+	AddBatch(ctx context.Context, db DBTX, entries []models.AuditEntry) error
+	// List возвращает до filter.Limit записей, подходящих под фильтр, новые первыми.
+	List(ctx context.Context, db DBTX, filter models.AuditFilter) ([]models.AuditEntry, error)
+}
+
+type Repository struct {
+	TeamRepository          TeamRepository
+	UserRepository          UserRepository
+	ReviewRepository        ReviewRepository
+	PullRequestRepository   PullRequestRepository
+	StatsRepository         StatsRepository
+	AbsenceRepository       AbsenceRepository
+	LoginRepository         LoginRepository
+	DeliveryRepository      DeliveryRepository
+	SubscriptionRepository  SubscriptionRepository
+	OutboxRepository        OutboxRepository
+	EventDeliveryRepository EventDeliveryRepository
+	TokenRepository         TokenRepository
+	AuditRepository         AuditRepository
 }
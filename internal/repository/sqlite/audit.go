@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type AuditRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *AuditRepository) Add(ctx context.Context, db repository.DBTX, entry *models.AuditEntry) error {
+	return r.AddBatch(ctx, db, []models.AuditEntry{*entry})
+}
+
+func (r *AuditRepository) AddBatch(ctx context.Context, db repository.DBTX, entries []models.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	createdAt := now()
+	query := r.builder.
+		Insert("audit_log").
+		Columns("action", "entity_type", "entity_id", "actor", "request_id", "before", "after", "created_at")
+	for _, entry := range entries {
+		query = query.Values(
+			entry.Action, entry.EntityType, entry.EntityID, entry.Actor, entry.RequestID,
+			jsonText(entry.Before), jsonText(entry.After), createdAt,
+		)
+	}
+
+	stmt, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("insert audit entries: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, db repository.DBTX, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	query := r.builder.
+		Select("id", "action", "entity_type", "entity_id", "actor", "request_id", "before", "after", "created_at").
+		From("audit_log").
+		OrderBy("id DESC").
+		Limit(uint64(filter.Limit))
+	if filter.EntityType != nil {
+		query = query.Where(squirrel.Eq{"entity_type": *filter.EntityType})
+	}
+	if filter.EntityID != nil {
+		query = query.Where(squirrel.Eq{"entity_id": *filter.EntityID})
+	}
+	if filter.Actor != nil {
+		query = query.Where(squirrel.Eq{"actor": *filter.Actor})
+	}
+	if filter.From != nil {
+		query = query.Where(squirrel.GtOrEq{"created_at": filter.From.UTC()})
+	}
+	if filter.To != nil {
+		query = query.Where(squirrel.Lt{"created_at": filter.To.UTC()})
+	}
+
+	stmt, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.AuditEntry
+	for rows.Next() {
+		var e models.AuditEntry
+		err := rows.Scan(
+			&e.ID,
+			&e.Action,
+			&e.EntityType,
+			&e.EntityID,
+			&e.Actor,
+			&e.RequestID,
+			&e.Before,
+			&e.After,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// jsonText хранит JSON строкой, а отсутствующий снимок — как NULL.
+func jsonText(data []byte) any {
+	if data == nil {
+		return nil
+	}
+
+	return string(data)
+}
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type DeliveryRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newDeliveryRepository() *DeliveryRepository {
+	return &DeliveryRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *DeliveryRepository) Exists(
+	ctx context.Context,
+	db repository.DBTX,
+	provider models.Provider,
+	deliveryID string,
+) (bool, error) {
+	stmt, args, err := r.builder.
+		Select("1").
+		From("webhook_deliveries").
+		Where(squirrel.Eq{"provider": provider, "delivery_id": deliveryID}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	var exists bool
+	err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("query row: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *DeliveryRepository) Save(
+	ctx context.Context,
+	db repository.DBTX,
+	provider models.Provider,
+	deliveryID string,
+	action models.WebhookAction,
+) (bool, error) {
+	stmt, args, err := r.builder.
+		Insert("webhook_deliveries").
+		Columns("provider", "delivery_id", "action", "received_at").
+		Values(provider, deliveryID, action, now()).
+		Suffix("ON CONFLICT (provider, delivery_id) DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	res, err := querier(db).ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return false, fmt.Errorf("execute query: %w", err)
+	}
+
+	return !noRowsAffected(res), nil
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type LoginRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newLoginRepository() *LoginRepository {
+	return &LoginRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *LoginRepository) Link(ctx context.Context, db repository.DBTX, login *models.UserLogin) error {
+	stmt, args, err := r.builder.
+		Insert("user_logins").
+		Columns("provider", "login", "user_id").
+		Values(login.Provider, login.Login, login.UserID).
+		Suffix("ON CONFLICT (provider, login) DO UPDATE SET user_id = EXCLUDED.user_id").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoginRepository) Unlink(
+	ctx context.Context,
+	db repository.DBTX,
+	provider models.Provider,
+	login string,
+) (*models.UserLogin, error) {
+	stmt, args, err := r.builder.
+		Delete("user_logins").
+		Where(squirrel.Eq{"provider": provider, "login": login}).
+		Suffix("RETURNING user_id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build delete: %w", err)
+	}
+
+	deleted := &models.UserLogin{Provider: provider, Login: login}
+	if err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(&deleted.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("delete login: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func (r *LoginRepository) Resolve(ctx context.Context, db repository.DBTX, provider models.Provider, login string) (string, error) {
+	stmt, args, err := r.builder.
+		Select("user_id").
+		From("user_logins").
+		Where(squirrel.Eq{"provider": provider, "login": login}).
+		ToSql()
+
+	if err != nil {
+		return "", fmt.Errorf("build query: %w", err)
+	}
+
+	var userID string
+	if err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", apperrors.ErrNotFound
+		}
+		return "", fmt.Errorf("execute query: %w", err)
+	}
+
+	return userID, nil
+}
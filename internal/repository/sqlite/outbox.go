@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type OutboxRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, db repository.DBTX, eventType models.EventType, payload []byte) error {
+	stmt, args, err := r.builder.
+		Insert("outbox").
+		Columns("event_type", "payload", "created_at").
+		Values(eventType, string(payload), now()).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("insert outbox event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) GetUndispatched(ctx context.Context, db repository.DBTX, limit int) ([]models.Event, error) {
+	stmt, args, err := r.builder.
+		Select("id", "event_type", "payload", "created_at").
+		From("outbox").
+		Where(squirrel.Eq{"dispatched_at": nil}).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.Event
+	for rows.Next() {
+		var event models.Event
+		if err := rows.Scan(&event.ID, &event.Type, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, db repository.DBTX, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stmt, args, err := r.builder.
+		Update("outbox").
+		Set("dispatched_at", now()).
+		Where(squirrel.Eq{"id": ids}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build update: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("mark outbox events dispatched: %w", err)
+	}
+
+	return nil
+}
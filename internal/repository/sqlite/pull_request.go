@@ -0,0 +1,237 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type PullRequestRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newPullRequestRepository() *PullRequestRepository {
+	return &PullRequestRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *PullRequestRepository) Create(ctx context.Context, db repository.DBTX, pr *models.PullRequest) error {
+	stmt, args, err := r.builder.
+		Insert("pull_requests").
+		Columns("id", "title", "author_id", "reviewers_required", "created_at", "source").
+		Values(pr.ID, pr.Title, pr.AuthorID, pr.ReviewersRequired, now(), pr.Source).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build create pr query: %w", err)
+	}
+
+	_, err = querier(db).ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return fmt.Errorf("exec create pr: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) GetByID(ctx context.Context, db repository.DBTX, id string) (*models.PullRequest, error) {
+	stmt, args, err := r.builder.
+		Select("id", "title", "author_id", "status", "merged_at", "closed_at", "reviewers_required", "source").
+		From("pull_requests").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var pr models.PullRequest
+	err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(
+		&pr.ID,
+		&pr.Title,
+		&pr.AuthorID,
+		&pr.Status,
+		&pr.MergedAt,
+		&pr.ClosedAt,
+		&pr.ReviewersRequired,
+		&pr.Source,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("scan pr: %w", err)
+	}
+
+	return &pr, nil
+}
+
+func (r *PullRequestRepository) Exists(ctx context.Context, db repository.DBTX, id string) (bool, error) {
+	stmt, args, err := r.builder.
+		Select("1").
+		From("pull_requests").
+		Where(squirrel.Eq{"id": id}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	var exists bool
+	err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("query row: %w", err)
+	}
+
+	return exists, nil
+}
+
+func (r *PullRequestRepository) UpdateMergeStatus(ctx context.Context, db repository.DBTX, id string) error {
+	stmt, args, err := r.builder.
+		Update("pull_requests").
+		Set("status", models.StatusMerged).
+		Set("merged_at", now()).
+		Where(squirrel.Eq{"id": id, "status": models.StatusOpen}).
+		ToSql()
+	// операция идемпотентная, обновление только для открытых pr-ов
+
+	if err != nil {
+		return fmt.Errorf("build merge: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("exec merge: %w", err)
+	}
+	// отсутствие обновления не является ошибкой
+
+	return nil
+}
+
+func (r *PullRequestRepository) UpdateCloseStatus(ctx context.Context, db repository.DBTX, id string) error {
+	stmt, args, err := r.builder.
+		Update("pull_requests").
+		Set("status", models.StatusClosed).
+		Set("closed_at", now()).
+		Where(squirrel.Eq{"id": id, "status": models.StatusOpen}).
+		ToSql()
+	// как и merge, закрыть можно только открытый pr, повторный вызов ничего не меняет
+
+	if err != nil {
+		return fmt.Errorf("build close: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("exec close: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) Reopen(ctx context.Context, db repository.DBTX, id string) error {
+	stmt, args, err := r.builder.
+		Update("pull_requests").
+		Set("status", models.StatusOpen).
+		Set("closed_at", nil).
+		Where(squirrel.Eq{"id": id, "status": models.StatusClosed}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build reopen: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("exec reopen: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) GetAssignedForUser(ctx context.Context, db repository.DBTX, userID string) ([]models.PullRequest, error) {
+	stmt, args, err := r.builder.
+		Select("pr.id", "pr.title", "pr.author_id", "pr.status").
+		From("pull_requests pr").
+		Join("reviewers r ON pr.id = r.pull_request_id").
+		Where(squirrel.Eq{"r.reviewer_id": userID}).
+		Where(squirrel.NotEq{"pr.status": models.StatusClosed}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.PullRequest
+	for rows.Next() {
+		var pr models.PullRequest
+		if err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.Status); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		result = append(result, pr)
+	}
+
+	return result, nil
+}
+
+// GetOpenReviewedBy возвращает открытые PR, где ревьювером назначен кто-то из reviewerIDs,
+// вместе с полным списком их текущих ревьюверов.
+func (r *PullRequestRepository) GetOpenReviewedBy(
+	ctx context.Context,
+	db repository.DBTX,
+	reviewerIDs []string,
+) ([]models.PullRequest, error) {
+	stmt, args, err := r.builder.
+		Select("pr.id", "pr.title", "pr.author_id", "pr.status", "pr.reviewers_required", "r.reviewer_id").
+		From("pull_requests pr").
+		Join("reviewers r ON pr.id = r.pull_request_id").
+		Where(squirrel.Eq{"pr.status": models.StatusOpen}).
+		Where(squirrel.Expr("pr.id IN (SELECT pull_request_id FROM reviewers WHERE ?)", squirrel.Eq{"reviewer_id": reviewerIDs})).
+		OrderBy("pr.id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.PullRequest
+	for rows.Next() {
+		var (
+			pr         models.PullRequest
+			reviewerID string
+		)
+		err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.Status, &pr.ReviewersRequired, &reviewerID)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+
+		if len(result) == 0 || result[len(result)-1].ID != pr.ID {
+			result = append(result, pr)
+		}
+		last := &result[len(result)-1]
+		last.AssignedReviewers = append(last.AssignedReviewers, reviewerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+)
+
+// Querier — запросы, через которые работают репозитории. QueryRowContext возвращает Row,
+// а не *sql.Row, чтобы ошибку можно было отдать и без обращения к БД.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) Row
+}
+
+// Row — то, что репозитории используют у *sql.Row.
+type Row interface {
+	Scan(dest ...any) error
+	Err() error
+}
+
+// sqlQuerier — общее подмножество *sql.DB и *sql.Tx.
+type sqlQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// querier приводит соединение, полученное сервисом от database.SQLite, к Querier.
+// Каждый запрос получает span с текстом SQL. На соединение другого хранилища все запросы
+// возвращают ErrForeignConn.
+func querier(db repository.DBTX) Querier {
+	q, ok := db.(sqlQuerier)
+	if !ok {
+		return failedQuerier{repository.ForeignConnError(config.StorageSQLite, db)}
+	}
+
+	return tracedQuerier{q}
+}
+
+type tracedQuerier struct {
+	sqlQuerier
+}
+
+func (q tracedQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := tracing.StartQuery(ctx, tracing.DBSystemSQLite, query)
+	res, err := q.sqlQuerier.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return res, err
+}
+
+func (q tracedQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := tracing.StartQuery(ctx, tracing.DBSystemSQLite, query)
+	rows, err := q.sqlQuerier.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return rows, err
+}
+
+func (q tracedQuerier) QueryRowContext(ctx context.Context, query string, args ...any) Row {
+	ctx, span := tracing.StartQuery(ctx, tracing.DBSystemSQLite, query)
+	row := q.sqlQuerier.QueryRowContext(ctx, query, args...)
+	tracing.End(span, row.Err())
+
+	return row
+}
+
+// failedQuerier отвечает ошибкой на любой запрос.
+type failedQuerier struct {
+	err error
+}
+
+func (q failedQuerier) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, q.err
+}
+
+func (q failedQuerier) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, q.err
+}
+
+func (q failedQuerier) QueryRowContext(context.Context, string, ...any) Row {
+	return failedRow(q)
+}
+
+type failedRow struct {
+	err error
+}
+
+func (r failedRow) Scan(...any) error {
+	return r.err
+}
+
+func (r failedRow) Err() error {
+	return r.err
+}
+
+// now заменяет NOW() из Postgres-реализации. Все метки времени пишутся из Go в UTC и в одном формате,
+// поэтому их можно сравнивать как строки прямо в SQL.
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+func noRowsAffected(res sql.Result) bool {
+	n, err := res.RowsAffected()
+	return err == nil && n == 0
+}
+
+func NewRepository() *repository.Repository {
+	return &repository.Repository{
+		TeamRepository:          newTeamRepository(),
+		UserRepository:          newUserRepository(),
+		ReviewRepository:        newReviewRepository(),
+		PullRequestRepository:   newPullRequestRepository(),
+		StatsRepository:         newStatsRepository(),
+		AbsenceRepository:       newAbsenceRepository(),
+		LoginRepository:         newLoginRepository(),
+		DeliveryRepository:      newDeliveryRepository(),
+		SubscriptionRepository:  newSubscriptionRepository(),
+		OutboxRepository:        newOutboxRepository(),
+		EventDeliveryRepository: newEventDeliveryRepository(),
+		TokenRepository:         newTokenRepository(),
+		AuditRepository:         newAuditRepository(),
+	}
+}
@@ -0,0 +1,229 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type SubscriptionRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newSubscriptionRepository() *SubscriptionRepository {
+	return &SubscriptionRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+// Create сохраняет подписку вместе со списком событий, вызывать нужно внутри транзакции.
+func (r *SubscriptionRepository) Create(ctx context.Context, db repository.DBTX, sub *models.Subscription) (int, error) {
+	stmt, args, err := r.builder.
+		Insert("webhook_subscriptions").
+		Columns("url", "secret", "is_active", "created_at").
+		Values(sub.URL, sub.Secret, sub.IsActive, now()).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	var id int
+	if err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("execute query: %w", err)
+	}
+
+	if err = r.insertEvents(ctx, db, id, sub.Events); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *SubscriptionRepository) GetByID(ctx context.Context, db repository.DBTX, id int) (*models.Subscription, error) {
+	subs, err := r.get(ctx, db, squirrel.Eq{"s.id": id})
+	if err != nil {
+		return nil, err
+	}
+	if len(subs) == 0 {
+		return nil, apperrors.ErrNotFound
+	}
+
+	return &subs[0], nil
+}
+
+func (r *SubscriptionRepository) List(ctx context.Context, db repository.DBTX) ([]models.Subscription, error) {
+	return r.get(ctx, db, nil)
+}
+
+// Update заменяет поля и список событий подписки, вызывать нужно внутри транзакции.
+func (r *SubscriptionRepository) Update(ctx context.Context, db repository.DBTX, sub *models.Subscription) error {
+	stmt, args, err := r.builder.
+		Update("webhook_subscriptions").
+		Set("url", sub.URL).
+		Set("is_active", sub.IsActive).
+		Where(squirrel.Eq{"id": sub.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build update: %w", err)
+	}
+
+	tag, err := querier(db).ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return fmt.Errorf("update subscription: %w", err)
+	}
+	if noRowsAffected(tag) {
+		return apperrors.ErrNotFound
+	}
+
+	stmt, args, err = r.builder.
+		Delete("webhook_subscription_events").
+		Where(squirrel.Eq{"subscription_id": sub.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build delete: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("delete subscription events: %w", err)
+	}
+
+	return r.insertEvents(ctx, db, sub.ID, sub.Events)
+}
+
+func (r *SubscriptionRepository) Delete(ctx context.Context, db repository.DBTX, id int) error {
+	stmt, args, err := r.builder.
+		Delete("webhook_subscriptions").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build delete: %w", err)
+	}
+
+	tag, err := querier(db).ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return fmt.Errorf("delete subscription: %w", err)
+	}
+	if noRowsAffected(tag) {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepository) GetActiveIDsByEvent(
+	ctx context.Context,
+	db repository.DBTX,
+	eventType models.EventType,
+) ([]int, error) {
+	stmt, args, err := r.builder.
+		Select("s.id").
+		From("webhook_subscriptions s").
+		Join("webhook_subscription_events e ON e.subscription_id = s.id").
+		Where(squirrel.Eq{"s.is_active": true, "e.event_type": eventType}).
+		OrderBy("s.id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *SubscriptionRepository) get(ctx context.Context, db repository.DBTX, where squirrel.Sqlizer) ([]models.Subscription, error) {
+	query := r.builder.
+		Select("s.id", "s.url", "s.secret", "s.is_active", "group_concat(e.event_type)").
+		From("webhook_subscriptions s").
+		Join("webhook_subscription_events e ON e.subscription_id = s.id").
+		GroupBy("s.id").
+		OrderBy("s.id")
+	if where != nil {
+		query = query.Where(where)
+	}
+
+	stmt, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []models.Subscription
+	for rows.Next() {
+		var (
+			sub    models.Subscription
+			events string
+		)
+		if err := rows.Scan(&sub.ID, &sub.URL, &sub.Secret, &sub.IsActive, &events); err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+		sub.Events = strings.Split(events, ",")
+		sort.Strings(sub.Events)
+		subs = append(subs, sub)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (r *SubscriptionRepository) insertEvents(ctx context.Context, db repository.DBTX, id int, events []models.EventType) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	query := r.builder.
+		Insert("webhook_subscription_events").
+		Columns("subscription_id", "event_type")
+	for _, event := range events {
+		query = query.Values(id, event)
+	}
+
+	stmt, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("insert subscription events: %w", err)
+	}
+
+	return nil
+}
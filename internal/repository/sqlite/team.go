@@ -0,0 +1,209 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type TeamRepository struct {
+	builder squirrel.StatementBuilderType
+}
+
+func newTeamRepository() *TeamRepository {
+	return &TeamRepository{
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *TeamRepository) Create(ctx context.Context, db repository.DBTX, team *models.Team) (int, error) {
+	stmt, args, err := r.builder.
+		Insert("teams").
+		Columns("name", "reviewer_strategy", "reviewers_required", "require_approvals", "chat_webhook_url").
+		Values(team.Name, team.ReviewerStrategy, team.ReviewersRequired, team.RequireApprovals, team.ChatWebhookURL).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	var id int
+	err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("execute query: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *TeamRepository) GetByID(ctx context.Context, db repository.DBTX, id int) (string, error) {
+	stmt, args, err := r.builder.
+		Select("name").
+		From("teams").
+		Where(squirrel.Eq{"id": id}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return "", fmt.Errorf("build query: %w", err)
+	}
+
+	var name string
+	err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(&name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", apperrors.ErrNotFound
+		}
+		return "", fmt.Errorf("execute query: %w", err)
+	}
+
+	return name, nil
+}
+
+func (r *TeamRepository) GetByName(ctx context.Context, db repository.DBTX, name string) (*models.Team, error) {
+	return r.getOne(ctx, db, squirrel.Eq{"t.name": name})
+}
+
+func (r *TeamRepository) GetByUserID(ctx context.Context, db repository.DBTX, userID string) (*models.Team, error) {
+	return r.getOne(ctx, db, squirrel.Expr("t.id = (SELECT team_id FROM users WHERE id = ?)", userID))
+}
+
+// GetByUserIDs возвращает команду каждого из пользователей; участники одной команды
+// получают один и тот же *models.Team.
+func (r *TeamRepository) GetByUserIDs(ctx context.Context, db repository.DBTX, userIDs []string) (map[string]*models.Team, error) {
+	stmt, args, err := r.builder.
+		Select("u.id", "t.id", "t.name", "t.reviewer_strategy", "t.reviewers_required",
+			"t.require_approvals", "t.last_reviewer_id", "t.chat_webhook_url").
+		From("teams t").
+		Join("users u ON u.team_id = t.id").
+		Where(squirrel.Eq{"u.id": userIDs}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := querier(db).QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[int]*models.Team)
+	byUser := make(map[string]*models.Team, len(userIDs))
+	for rows.Next() {
+		var (
+			userID string
+			team   models.Team
+		)
+		err := rows.Scan(
+			&userID,
+			&team.ID,
+			&team.Name,
+			&team.ReviewerStrategy,
+			&team.ReviewersRequired,
+			&team.RequireApprovals,
+			&team.LastReviewerID,
+			&team.ChatWebhookURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan row: %w", err)
+		}
+
+		if _, ok := byID[team.ID]; !ok {
+			byID[team.ID] = &team
+		}
+		byUser[userID] = byID[team.ID]
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return byUser, nil
+}
+
+func (r *TeamRepository) UpdateSettings(ctx context.Context, db repository.DBTX, team *models.Team) error {
+	stmt, args, err := r.builder.
+		Update("teams").
+		Set("reviewer_strategy", team.ReviewerStrategy).
+		Set("reviewers_required", team.ReviewersRequired).
+		Set("require_approvals", team.RequireApprovals).
+		Set("chat_webhook_url", team.ChatWebhookURL).
+		Where(squirrel.Eq{"id": team.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	cmdTag, err := querier(db).ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return fmt.Errorf("execute query: %w", err)
+	}
+
+	if noRowsAffected(cmdTag) {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) UpdateLastReviewer(ctx context.Context, db repository.DBTX, teamID int, reviewerID string) error {
+	stmt, args, err := r.builder.
+		Update("teams").
+		Set("last_reviewer_id", reviewerID).
+		Where(squirrel.Eq{"id": teamID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err = querier(db).ExecContext(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("execute query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) getOne(ctx context.Context, db repository.DBTX, pred squirrel.Sqlizer) (*models.Team, error) {
+	stmt, args, err := r.builder.
+		Select("t.id", "t.name", "t.reviewer_strategy", "t.reviewers_required",
+			"t.require_approvals", "t.last_reviewer_id", "t.chat_webhook_url").
+		From("teams t").
+		Where(pred).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	team := &models.Team{}
+	err = querier(db).QueryRowContext(ctx, stmt, args...).Scan(
+		&team.ID,
+		&team.Name,
+		&team.ReviewerStrategy,
+		&team.ReviewersRequired,
+		&team.RequireApprovals,
+		&team.LastReviewerID,
+		&team.ChatWebhookURL,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("execute query: %w", err)
+	}
+
+	return team, nil
+}
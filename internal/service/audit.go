@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/auth"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+)
+
+// requestIDKey — ключ, под которым middleware requestid кладёт id запроса в контекст.
+const requestIDKey = "requestid"
+
+// anonymousActor записывается в аудит, когда аутентификация выключена.
+const anonymousActor = "anonymous"
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditService struct {
+	db        database.Storage
+	auditRepo repository.AuditRepository
+}
+
+func newAuditService(db database.Storage, auditRepo repository.AuditRepository) *AuditService {
+	return &AuditService{
+		db:        db,
+		auditRepo: auditRepo,
+	}
+}
+
+// List возвращает записи журнала аудита, новые первыми.
+func (s *AuditService) List(ctx context.Context, filter models.AuditFilter) ([]models.AuditEntry, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.List")
+	defer span.End()
+
+	if filter.EntityType != nil && !isKnownAuditEntity(*filter.EntityType) {
+		return nil, apperrors.ErrUnknownEntity
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	filter.Limit = min(filter.Limit, maxAuditLimit)
+
+	// created_at хранится как TIMESTAMP без зоны в UTC
+	if filter.From != nil {
+		from := filter.From.UTC()
+		filter.From = &from
+	}
+	if filter.To != nil {
+		to := filter.To.UTC()
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, apperrors.ErrInvalidPeriod
+	}
+
+	return s.auditRepo.List(ctx, s.db.Conn(), filter)
+}
+
+// writeAudit записывает в журнал аудита изменение сущности в транзакции tx, чтобы запись
+// появилась ровно тогда, когда закоммитится само изменение. before и after сохраняются
+// как JSON; nil означает, что сущности до или после изменения не было.
+func writeAudit(
+	ctx context.Context,
+	auditRepo repository.AuditRepository,
+	tx database.Tx,
+	action models.AuditAction,
+	entityType models.AuditEntity,
+	entityID string,
+	before, after any,
+) error {
+	entry, err := newAuditEntry(ctx, action, entityType, entityID, before, after)
+	if err != nil {
+		return err
+	}
+
+	return auditRepo.Add(ctx, tx, entry)
+}
+
+// newAuditEntry собирает запись аудита с автором и id запроса из ctx; для массовых
+// изменений записи копятся и пишутся одним AddBatch.
+func newAuditEntry(
+	ctx context.Context,
+	action models.AuditAction,
+	entityType models.AuditEntity,
+	entityID string,
+	before, after any,
+) (*models.AuditEntry, error) {
+	entry := &models.AuditEntry{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Actor:      anonymousActor,
+	}
+
+	if identity := auth.FromContext(ctx); identity != nil {
+		entry.Actor = identity.Subject
+	}
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok && requestID != "" {
+		entry.RequestID = &requestID
+	}
+
+	var err error
+	if entry.Before, err = auditSnapshot(before); err != nil {
+		return nil, fmt.Errorf("marshal %s audit snapshot: %w", action, err)
+	}
+	if entry.After, err = auditSnapshot(after); err != nil {
+		return nil, fmt.Errorf("marshal %s audit snapshot: %w", action, err)
+	}
+
+	return entry, nil
+}
+
+// pullRequestSnapshot — состояние PR в журнале аудита.
+type pullRequestSnapshot struct {
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Status            string     `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	MergedAt          *time.Time `json:"merged_at,omitempty"`
+}
+
+func pullRequestAudit(pr *models.PullRequest) *pullRequestSnapshot {
+	return &pullRequestSnapshot{
+		PullRequestID:     pr.ID,
+		PullRequestName:   pr.Title,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: nonNil(slices.Clone(pr.AssignedReviewers)),
+		MergedAt:          pr.MergedAt,
+	}
+}
+
+// userActivitySnapshot — пользователь и открытые PR, где он ревьювер; при деактивации
+// в снимок «после» попадают и переназначения его ревью.
+type userActivitySnapshot struct {
+	User          *api.User                `json:"user"`
+	OpenReviews   []string                 `json:"open_reviews"`
+	Reassignments []reviewerReassignedData `json:"reassignments,omitempty"`
+}
+
+func userActivityAudit(user *api.User, openReviews []string, reassignments []models.Reassignment) *userActivitySnapshot {
+	snapshot := &userActivitySnapshot{User: user, OpenReviews: nonNil(openReviews)}
+	for _, r := range reassignments {
+		snapshot.Reassignments = append(snapshot.Reassignments, reviewerReassignedData{
+			PullRequestID: r.PullRequestID,
+			OldReviewers:  r.OldReviewers,
+			NewReviewers:  nonNil(r.NewReviewers),
+		})
+	}
+
+	return snapshot
+}
+
+func pullRequestIDs(prs []models.PullRequest) []string {
+	ids := make([]string, len(prs))
+	for i, pr := range prs {
+		ids[i] = pr.ID
+	}
+
+	return ids
+}
+
+func auditSnapshot(v any) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(v)
+}
+
+func isKnownAuditEntity(entity models.AuditEntity) bool {
+	switch entity {
+	case models.AuditEntityTeam, models.AuditEntityUser, models.AuditEntityPullRequest:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/auth"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+)
+
+// tokenPrefix помогает узнать токен сервиса в логах и сканерах секретов.
+const tokenPrefix = "rprs_"
+
+const maxTokenNameLength = 100
+
+// adminTokenSubject — субъект запросов с токеном из ADMIN_TOKEN.
+const adminTokenSubject = "admin"
+
+type AuthService struct {
+	db         database.Storage
+	tokenRepo  repository.TokenRepository
+	adminToken string
+}
+
+func newAuthService(db database.Storage, tokenRepo repository.TokenRepository, adminToken string) *AuthService {
+	return &AuthService{
+		db:         db,
+		tokenRepo:  tokenRepo,
+		adminToken: adminToken,
+	}
+}
+
+// Authenticate возвращает владельца токена. Токен из ADMIN_TOKEN всегда считается
+// администраторским, остальные ищутся в БД по хешу; отозванные не принимаются.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*auth.Identity, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Authenticate")
+	defer span.End()
+
+	if s.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) == 1 {
+		return &auth.Identity{Subject: adminTokenSubject, Role: models.RoleAdmin}, nil
+	}
+
+	apiToken, err := s.tokenRepo.GetByHash(ctx, s.db.Conn(), hashToken(token))
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, apperrors.ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if apiToken.RevokedAt != nil {
+		return nil, apperrors.ErrUnauthenticated
+	}
+
+	return &auth.Identity{Subject: apiToken.Name, Role: apiToken.Role}, nil
+}
+
+// Issue выпускает токен и возвращает его вместе с открытым значением, которое больше нигде не хранится.
+func (s *AuthService) Issue(ctx context.Context, name string, role models.Role) (*models.APIToken, string, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Issue")
+	defer span.End()
+
+	if name == "" || utf8.RuneCountInString(name) > maxTokenNameLength {
+		return nil, "", apperrors.ErrInvalidTokenName
+	}
+	if role != models.RoleAdmin && role != models.RoleUser {
+		return nil, "", apperrors.ErrUnknownRole
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("generate token: %w", err)
+	}
+	token := tokenPrefix + hex.EncodeToString(secret)
+
+	id, err := s.tokenRepo.Create(ctx, s.db.Conn(), &models.APIToken{Name: name, Role: role, Hash: hashToken(token)})
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiToken, err := s.tokenRepo.GetByID(ctx, s.db.Conn(), id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return apiToken, token, nil
+}
+
+func (s *AuthService) List(ctx context.Context) ([]models.APIToken, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.List")
+	defer span.End()
+
+	return s.tokenRepo.List(ctx, s.db.Conn())
+}
+
+// Revoke отзывает токен; повторный отзыв ничего не меняет.
+func (s *AuthService) Revoke(ctx context.Context, id int) (*models.APIToken, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Revoke")
+	defer span.End()
+
+	if err := s.tokenRepo.Revoke(ctx, s.db.Conn(), id); err != nil {
+		return nil, err
+	}
+
+	return s.tokenRepo.GetByID(ctx, s.db.Conn(), id)
+}
+
+// HasAdminToken сообщает, есть ли чем администрировать API: ADMIN_TOKEN или неотозванный токен с ролью ADMIN в БД.
+func (s *AuthService) HasAdminToken(ctx context.Context) (bool, error) {
+	if s.adminToken != "" {
+		return true, nil
+	}
+
+	tokens, err := s.tokenRepo.List(ctx, s.db.Conn())
+	if err != nil {
+		return false, err
+	}
+
+	for _, token := range tokens {
+		if token.RevokedAt == nil && token.Role == models.RoleAdmin {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// hashToken — SHA-256 в hex. Токены случайные и длинные, поэтому медленный хеш им не нужен.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+)
+
+const (
+	// fanOutBatchSize — сколько событий outbox раскладывается по подпискам за одну транзакцию.
+	fanOutBatchSize = 100
+	// deliveryBatchSize — сколько доставок отправляется параллельно за один проход.
+	deliveryBatchSize = 20
+	deliveryTimeout   = 10 * time.Second
+	// deliveryLease — на сколько откладывается следующая попытка взятой в работу доставки.
+	// Если экземпляр упадёт посреди отправки, доставку повторят после этого срока.
+	deliveryLease   = 6 * deliveryTimeout
+	maxRetryBackoff = time.Hour
+)
+
+// EventDispatcher рассылает события из outbox подписчикам вебхуков.
+//
+// Каждый проход сначала раскладывает новые события outbox по активным подпискам (журнал доставок),
+// затем отправляет доставки, время которых подошло. Неудачная попытка повторяется с экспоненциальной
+// задержкой, после maxAttempts попыток доставка помечается FAILED. Доставка «как минимум один раз»:
+// подписчик должен отбрасывать повторы по заголовку X-Webhook-Delivery.
+type EventDispatcher struct {
+	db               database.Storage
+	outboxRepo       repository.OutboxRepository
+	subscriptionRepo repository.SubscriptionRepository
+	deliveryRepo     repository.EventDeliveryRepository
+	client           *http.Client
+	pollInterval     time.Duration
+	maxAttempts      int
+	retryBackoff     time.Duration
+}
+
+func newEventDispatcher(
+	db database.Storage,
+	outboxRepo repository.OutboxRepository,
+	subscriptionRepo repository.SubscriptionRepository,
+	deliveryRepo repository.EventDeliveryRepository,
+	cfg *config.Config,
+) *EventDispatcher {
+	return &EventDispatcher{
+		db:               db,
+		outboxRepo:       outboxRepo,
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		client:           &http.Client{Timeout: deliveryTimeout},
+		pollInterval:     cfg.WebhookPollInterval,
+		maxAttempts:      max(cfg.WebhookMaxAttempts, 1),
+		retryBackoff:     cfg.WebhookRetryBackoff,
+	}
+}
+
+// Run обрабатывает outbox каждые pollInterval, пока не отменён ctx.
+func (d *EventDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.fanOut(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "webhook dispatcher: fan out events", logging.Error(err))
+		}
+		if err := d.deliverDue(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "webhook dispatcher: deliver events", logging.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fanOut создаёт доставки для всех ещё не разосланных событий outbox.
+func (d *EventDispatcher) fanOut(ctx context.Context) error {
+	for {
+		n, err := d.fanOutBatch(ctx)
+		if err != nil || n < fanOutBatchSize {
+			return err
+		}
+	}
+}
+
+func (d *EventDispatcher) fanOutBatch(ctx context.Context) (int, error) {
+	var dispatched int
+	err := database.RunInTx(ctx, d.db, func(tx database.Tx) error {
+		events, err := d.outboxRepo.GetUndispatched(ctx, tx, fanOutBatchSize)
+		if err != nil {
+			return err
+		}
+		dispatched = len(events)
+		if len(events) == 0 {
+			return nil
+		}
+
+		subscribers := make(map[models.EventType][]int)
+		eventIDs := make([]int64, 0, len(events))
+		var deliveries []models.EventDelivery
+		for _, event := range events {
+			subIDs, ok := subscribers[event.Type]
+			if !ok {
+				if subIDs, err = d.subscriptionRepo.GetActiveIDsByEvent(ctx, tx, event.Type); err != nil {
+					return err
+				}
+				subscribers[event.Type] = subIDs
+			}
+
+			for _, subID := range subIDs {
+				deliveries = append(deliveries, models.EventDelivery{SubscriptionID: subID, EventID: event.ID})
+			}
+			eventIDs = append(eventIDs, event.ID)
+		}
+
+		if err = d.deliveryRepo.CreateBatch(ctx, tx, deliveries); err != nil {
+			return err
+		}
+
+		return d.outboxRepo.MarkDispatched(ctx, tx, eventIDs)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return dispatched, nil
+}
+
+// deliverDue отправляет доставки, время которых подошло, пока они не закончатся.
+func (d *EventDispatcher) deliverDue(ctx context.Context) error {
+	for ctx.Err() == nil {
+		claimed, err := d.claim(ctx)
+		if err != nil || len(claimed) == 0 {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range claimed {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d.deliver(ctx, &delivery)
+			}()
+		}
+		wg.Wait()
+
+		if len(claimed) < deliveryBatchSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (d *EventDispatcher) claim(ctx context.Context) ([]models.PendingDelivery, error) {
+	var claimed []models.PendingDelivery
+	err := database.RunInTx(ctx, d.db, func(tx database.Tx) error {
+		var err error
+		claimed, err = d.deliveryRepo.ClaimDue(ctx, tx, deliveryLease, deliveryBatchSize)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// deliver делает одну попытку доставки и записывает её результат в журнал.
+func (d *EventDispatcher) deliver(ctx context.Context, delivery *models.PendingDelivery) {
+	statusCode, err := d.send(ctx, delivery)
+	if ctx.Err() != nil {
+		// сервис останавливается: попытку повторят после истечения deliveryLease
+		return
+	}
+
+	attempt := &models.DeliveryAttempt{Status: models.DeliveryDelivered}
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+	if err != nil {
+		message := err.Error()
+		attempt.Error = &message
+
+		attempts := delivery.Attempts + 1
+		if attempts >= d.maxAttempts {
+			attempt.Status = models.DeliveryFailed
+			slog.WarnContext(ctx, "webhook dispatcher: delivery failed",
+				slog.Int64("delivery_id", delivery.ID),
+				slog.String("url", delivery.URL),
+				slog.Int("attempts", attempts),
+				logging.Error(err),
+			)
+		} else {
+			attempt.Status = models.DeliveryPending
+			attempt.RetryIn = d.backoff(attempts)
+		}
+	}
+
+	if err = d.deliveryRepo.SaveAttempt(ctx, d.db.Conn(), delivery.ID, attempt); err != nil {
+		slog.ErrorContext(ctx, "webhook dispatcher: save delivery attempt", slog.Int64("delivery_id", delivery.ID), logging.Error(err))
+	}
+}
+
+// envelope — тело запроса к подписчику.
+type envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// send отправляет событие подписчику. Тело подписывается HMAC-SHA256 секретом подписки,
+// подпись передаётся в X-Webhook-Signature-256 в формате sha256=<hex>, как у GitHub.
+// Успехом считается любой ответ 2xx.
+func (d *EventDispatcher) send(ctx context.Context, delivery *models.PendingDelivery) (int, error) {
+	body, err := json.Marshal(envelope{
+		ID:        delivery.Event.ID,
+		Type:      delivery.Event.Type,
+		CreatedAt: delivery.Event.CreatedAt,
+		Data:      delivery.Event.Payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(delivery.Secret))
+	mac.Write(body)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "review-pull-request-service")
+	req.Header.Set("X-Webhook-Event", delivery.Event.Type)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff возвращает задержку перед следующей попыткой: retryBackoff, затем вдвое больше
+// после каждой неудачи, но не больше maxRetryBackoff.
+func (d *EventDispatcher) backoff(attempts int) time.Duration {
+	delay := d.retryBackoff
+	for i := 1; i < attempts && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryBackoff)
+}
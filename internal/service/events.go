@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+)
+
+// Содержимое поля data в событиях для подписчиков вебхуков.
+
+type pullRequestCreatedData struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	Source            *string  `json:"source,omitempty"`
+}
+
+type reviewerAssignedData struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+}
+
+type reviewerReassignedData struct {
+	PullRequestID string   `json:"pull_request_id"`
+	OldReviewers  []string `json:"old_reviewers"`
+	NewReviewers  []string `json:"new_reviewers"`
+}
+
+type pullRequestMergedData struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	MergedAt        *time.Time `json:"merged_at"`
+}
+
+// emit записывает событие в outbox в транзакции tx: подписчики получат его, только если она закоммитится.
+func (s *PullRequestService) emit(ctx context.Context, tx database.Tx, eventType models.EventType, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", eventType, err)
+	}
+
+	return s.outboxRepo.Add(ctx, tx, eventType, payload)
+}
+
+// emitAssigned пишет по событию reviewer.assigned на каждого нового ревьювера PR.
+func (s *PullRequestService) emitAssigned(ctx context.Context, tx database.Tx, prID string, reviewerIDs []string) error {
+	for _, reviewerID := range reviewerIDs {
+		err := s.emit(ctx, tx, models.EventReviewerAssigned, reviewerAssignedData{PullRequestID: prID, ReviewerID: reviewerID})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// emitReassigned пишет reviewer.reassigned и reviewer.assigned для каждой замены ревьюверов.
+func (s *PullRequestService) emitReassigned(ctx context.Context, tx database.Tx, reassignments []models.Reassignment) error {
+	for _, r := range reassignments {
+		err := s.emit(ctx, tx, models.EventReviewerReassigned, reviewerReassignedData{
+			PullRequestID: r.PullRequestID,
+			OldReviewers:  r.OldReviewers,
+			NewReviewers:  nonNil(r.NewReviewers),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = s.emitAssigned(ctx, tx, r.PullRequestID, r.NewReviewers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nonNil заменяет nil на пустой срез, чтобы в JSON был [], а не null.
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+
+	return ids
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+)
+
+// readinessTimeout ограничивает все проверки готовности вместе: пробы балансировщиков ждут ответа 1–3 секунды.
+const readinessTimeout = 2 * time.Second
+
+// HealthService отвечает на пробы живости и готовности.
+type HealthService struct {
+	db database.Storage
+	// latestMigration — версия схемы, с которой собран бинарник; migrationsErr == ErrNoMigrations для memory.
+	latestMigration uint
+	migrationsErr   error
+	shuttingDown    atomic.Bool
+}
+
+func newHealthService(db database.Storage, cfg *config.Config) *HealthService {
+	latest, err := database.LatestMigration(cfg.Storage)
+
+	return &HealthService{
+		db:              db,
+		latestMigration: latest,
+		migrationsErr:   err,
+	}
+}
+
+// SetShuttingDown переводит готовность в DOWN. Вызывается в начале graceful shutdown,
+// чтобы балансировщик успел перестать присылать запросы до остановки сервера.
+func (s *HealthService) SetShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+// Ready проверяет соединение с БД, версию схемы и заполненность пула.
+// Схема старее сборки или «грязная» после упавшей миграции — DOWN; схема новее сборки
+// (во время выкатки её уже обновила новая реплика) и пул без свободных соединений — DEGRADED.
+func (s *HealthService) Ready(ctx context.Context) *models.Readiness {
+	ctx, span := tracing.Start(ctx, "HealthService.Ready")
+	defer span.End()
+
+	if s.shuttingDown.Load() {
+		return &models.Readiness{
+			Status:       models.HealthDown,
+			ShuttingDown: true,
+			Components:   map[string]models.HealthComponent{},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	components := map[string]models.HealthComponent{
+		"database": s.checkDatabase(ctx),
+	}
+	if !errors.Is(s.migrationsErr, database.ErrNoMigrations) {
+		components["migrations"] = s.checkMigrations(ctx)
+	}
+	if stats := s.db.PoolStats(); stats.Max > 0 {
+		components["pool"] = checkPool(stats)
+	}
+
+	readiness := &models.Readiness{Status: models.HealthUp, Components: components}
+	for _, component := range components {
+		switch component.Status {
+		case models.HealthDown:
+			readiness.Status = models.HealthDown
+		case models.HealthDegraded:
+			if readiness.Status == models.HealthUp {
+				readiness.Status = models.HealthDegraded
+			}
+		}
+	}
+
+	return readiness
+}
+
+func (s *HealthService) checkDatabase(ctx context.Context) models.HealthComponent {
+	if err := s.db.HealthCheck(ctx); err != nil {
+		slog.WarnContext(ctx, "readiness: database is unreachable", logging.Error(err))
+		return models.HealthComponent{Status: models.HealthDown, Error: "database is unreachable"}
+	}
+
+	return models.HealthComponent{Status: models.HealthUp}
+}
+
+func (s *HealthService) checkMigrations(ctx context.Context) models.HealthComponent {
+	if s.migrationsErr != nil {
+		slog.WarnContext(ctx, "readiness: read embedded migrations", logging.Error(s.migrationsErr))
+		return models.HealthComponent{Status: models.HealthDown, Error: "embedded migrations are unreadable"}
+	}
+
+	version, dirty, err := s.db.SchemaVersion(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "readiness: read schema version", logging.Error(err))
+		return models.HealthComponent{Status: models.HealthDown, Error: "schema version is unavailable"}
+	}
+
+	component := models.HealthComponent{
+		Status: models.HealthUp,
+		Details: map[string]any{
+			"version":          version,
+			"expected_version": s.latestMigration,
+			"dirty":            dirty,
+		},
+	}
+	switch {
+	case dirty:
+		component.Status, component.Error = models.HealthDown, "last migration failed, schema is dirty"
+	case version < s.latestMigration:
+		component.Status, component.Error = models.HealthDown, "schema is older than this build"
+	case version > s.latestMigration:
+		component.Status, component.Error = models.HealthDegraded, "schema is newer than this build"
+	}
+
+	return component
+}
+
+func checkPool(stats database.PoolStats) models.HealthComponent {
+	component := models.HealthComponent{
+		Status: models.HealthUp,
+		Details: map[string]any{
+			"in_use":     stats.InUse,
+			"idle":       stats.Idle,
+			"max":        stats.Max,
+			"wait_count": stats.WaitCount,
+		},
+	}
+	if stats.InUse >= stats.Max {
+		component.Status, component.Error = models.HealthDegraded, "all connections are in use"
+	}
+
+	return component
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const chatTimeout = 5 * time.Second
+
+// defaultChatTemplate используется, если CHAT_MESSAGE_TEMPLATE не задан.
+// Разметка (*жирный*, эмодзи) понятна и Slack, и Mattermost.
+const defaultChatTemplate = `{{if eq .Event "pr.created"}}:eyes: PR *{{.PullRequestName}}* ({{.PullRequestID}}) от {{.AuthorID}}: ` +
+	`{{with .Reviewers}}ревьюверы {{.}}{{else}}свободных ревьюверов нет{{end}}` +
+	`{{else if eq .Event "reviewer.reassigned"}}:arrows_counterclockwise: PR *{{.PullRequestName}}* ({{.PullRequestID}}): ` +
+	`ревьювер {{.OldReviewers}} заменён на {{.Reviewers}}` +
+	`{{else if eq .Event "pr.merged"}}:white_check_mark: PR *{{.PullRequestName}}* ({{.PullRequestID}}) от {{.AuthorID}} смёржен{{end}}`
+
+// ChatMessage — данные для шаблона уведомления. Event — один из pr.created, reviewer.reassigned, pr.merged;
+// списки ревьюверов передаются строкой через запятую.
+type ChatMessage struct {
+	Event           models.EventType
+	Team            string
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	Reviewers       string
+	OldReviewers    string
+}
+
+// ChatNotifier отправляет уведомления в чат команды через incoming webhook (Slack или Mattermost).
+type ChatNotifier struct {
+	client   *http.Client
+	template *template.Template
+}
+
+func newChatNotifier(cfg *config.Config) *ChatNotifier {
+	text := cfg.ChatMessageTemplate
+	if text == "" {
+		text = defaultChatTemplate
+	}
+
+	return &ChatNotifier{
+		client: &http.Client{Timeout: chatTimeout},
+		// шаблон уже проверен в config.Load
+		template: template.Must(template.New("chat").Parse(text)),
+	}
+}
+
+// Notify отправляет сообщение в чат команды в фоне, если у неё задан chat_webhook_url.
+// Вызывается после коммита, поэтому ошибки отправки только логируются и на ответ API не влияют.
+// Пустое после шаблона сообщение не отправляется — так шаблон может отключать отдельные события.
+func (n *ChatNotifier) Notify(ctx context.Context, team *models.Team, msg ChatMessage) {
+	if team == nil || team.ChatWebhookURL == nil {
+		return
+	}
+	msg.Team = team.Name
+
+	var text strings.Builder
+	if err := n.template.Execute(&text, msg); err != nil {
+		slog.ErrorContext(ctx, "chat notifier: render message",
+			slog.String("event", msg.Event), slog.String("team", team.Name), logging.Error(err))
+		return
+	}
+	if strings.TrimSpace(text.String()) == "" {
+		return
+	}
+
+	endpoint := *team.ChatWebhookURL
+	// контекст запроса после ответа использовать нельзя, в фон переносятся только атрибуты лога и span
+	sendCtx := trace.ContextWithSpanContext(logging.Detach(ctx), trace.SpanContextFromContext(ctx))
+	go func() {
+		ctx, cancel := context.WithTimeout(sendCtx, chatTimeout)
+		defer cancel()
+
+		if err := n.send(ctx, endpoint, text.String()); err != nil {
+			slog.WarnContext(ctx, "chat notifier: send message",
+				slog.String("event", msg.Event), slog.String("team", msg.Team), logging.Error(err))
+		}
+	}()
+}
+
+// send публикует сообщение в формате incoming webhook: {"text": "..."}.
+func (n *ChatNotifier) send(ctx context.Context, endpoint, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
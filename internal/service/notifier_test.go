@@ -0,0 +1,220 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// chatStandIn — локальная замена incoming webhook Slack/Mattermost: отдаёт тексты принятых сообщений в канал.
+type chatStandIn struct {
+	*httptest.Server
+	messages chan string
+}
+
+func newChatStandIn(t *testing.T, handle func(r *http.Request)) *chatStandIn {
+	t.Helper()
+
+	chat := &chatStandIn{messages: make(chan string, 10)}
+	chat.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handle != nil {
+			handle(r)
+		}
+
+		var body struct {
+			Text string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		chat.messages <- body.Text
+	}))
+	t.Cleanup(chat.Close)
+
+	return chat
+}
+
+func (c *chatStandIn) team(name string) *models.Team {
+	return &models.Team{Name: name, ChatWebhookURL: &c.URL}
+}
+
+func (c *chatStandIn) wait(t *testing.T) string {
+	t.Helper()
+
+	select {
+	case text := <-c.messages:
+		return text
+	case <-time.After(2 * time.Second):
+		t.Fatal("chat message was not delivered")
+		return ""
+	}
+}
+
+func (c *chatStandIn) expectNone(t *testing.T) {
+	t.Helper()
+
+	select {
+	case text := <-c.messages:
+		t.Fatalf("unexpected chat message %q", text)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestChatNotifierPostsToTeamURL(t *testing.T) {
+	backend := newChatStandIn(t, nil)
+	frontend := newChatStandIn(t, nil)
+	notifier := newChatNotifier(&config.Config{ChatMessageTemplate: "{{.Team}}: {{.PullRequestID}}"})
+
+	notifier.Notify(context.Background(), backend.team("backend"), ChatMessage{
+		Event:         models.EventPullRequestCreated,
+		PullRequestID: "pr-1",
+	})
+
+	if got := backend.wait(t); got != "backend: pr-1" {
+		t.Errorf("message = %q, want %q", got, "backend: pr-1")
+	}
+	frontend.expectNone(t)
+
+	// без chat_webhook_url уведомление не отправляется
+	notifier.Notify(context.Background(), &models.Team{Name: "frontend"}, ChatMessage{Event: models.EventPullRequestCreated})
+	frontend.expectNone(t)
+}
+
+func TestChatNotifierTemplate(t *testing.T) {
+	msg := ChatMessage{
+		Event:           models.EventPullRequestCreated,
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+		Reviewers:       "u2, u3",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		msg      ChatMessage
+		want     string
+	}{
+		{
+			name: "default template",
+			msg:  msg,
+			want: ":eyes: PR *Add search* (pr-1) от u1: ревьюверы u2, u3",
+		},
+		{
+			name: "default template without reviewers",
+			msg:  ChatMessage{Event: models.EventPullRequestCreated, PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1"},
+			want: ":eyes: PR *Add search* (pr-1) от u1: свободных ревьюверов нет",
+		},
+		{
+			name:     "custom template",
+			template: "[{{.Team}}] {{.Event}} {{.PullRequestID}} -> {{.Reviewers}}",
+			msg:      msg,
+			want:     "[backend] pr.created pr-1 -> u2, u3",
+		},
+		{
+			name:     "template skips the event",
+			template: `{{if eq .Event "pr.merged"}}merged{{end}}`,
+			msg:      msg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chat := newChatStandIn(t, nil)
+			notifier := newChatNotifier(&config.Config{ChatMessageTemplate: tt.template})
+
+			notifier.Notify(context.Background(), chat.team("backend"), tt.msg)
+
+			if tt.want == "" {
+				chat.expectNone(t)
+				return
+			}
+			if got := chat.wait(t); got != tt.want {
+				t.Errorf("message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// syncBuffer собирает вывод логгера из горутины уведомлений.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestChatNotifierOutlivesRequest(t *testing.T) {
+	var logs syncBuffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	// чат отвечает, когда HTTP-запрос к сервису уже завершён и его контекст отменён
+	requestDone := make(chan struct{})
+	chat := newChatStandIn(t, func(r *http.Request) {
+		<-requestDone
+		time.Sleep(20 * time.Millisecond)
+	})
+	notifier := newChatNotifier(&config.Config{ChatMessageTemplate: "{{.PullRequestID}}"})
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		// как middleware таймаута: контекст запроса строится от fasthttp.RequestCtx и отменяется после ответа
+		ctx, cancel := context.WithCancel(c.Context())
+		defer cancel()
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	})
+	app.Post("/merge", func(c *fiber.Ctx) error {
+		notifier.Notify(c.UserContext(), chat.team("backend"), ChatMessage{
+			Event:         models.EventPullRequestMerged,
+			PullRequestID: "pr-1",
+		})
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/merge", nil), -1)
+	if err != nil {
+		t.Fatalf("send request: %v", err)
+	}
+	resp.Body.Close()
+	close(requestDone)
+
+	if got := chat.wait(t); got != "pr-1" {
+		t.Errorf("message = %q, want %q", got, "pr-1")
+	}
+
+	// ошибка отправки логируется уже после того, как чат принял сообщение
+	time.Sleep(50 * time.Millisecond)
+	if out := logs.String(); out != "" {
+		t.Errorf("unexpected notifier log: %s", out)
+	}
+}
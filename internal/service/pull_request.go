@@ -2,172 +2,642 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
+	"strings"
 
 	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
 	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/metrics"
 	"github.com/AntonTsoy/review-pull-request-service/internal/models"
 	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
-	"github.com/jackc/pgx/v5"
 )
 
 type PullRequestService struct {
-	db         *database.Database
-	prRepo     *repository.PullRequestRepository
-	userRepo   *repository.UserRepository
-	reviewRepo *repository.ReviewRepository
+	db         database.Storage
+	prRepo     repository.PullRequestRepository
+	userRepo   repository.UserRepository
+	reviewRepo repository.ReviewRepository
+	teamRepo   repository.TeamRepository
+	outboxRepo repository.OutboxRepository
+	auditRepo  repository.AuditRepository
+	notifier   *ChatNotifier
 }
 
 func newPullRequestService(
-	db *database.Database,
-	prRepo *repository.PullRequestRepository,
-	userRepo *repository.UserRepository,
-	reviewRepo *repository.ReviewRepository,
+	db database.Storage,
+	prRepo repository.PullRequestRepository,
+	userRepo repository.UserRepository,
+	reviewRepo repository.ReviewRepository,
+	teamRepo repository.TeamRepository,
+	outboxRepo repository.OutboxRepository,
+	auditRepo repository.AuditRepository,
+	notifier *ChatNotifier,
 ) *PullRequestService {
 	return &PullRequestService{
 		db:         db,
 		prRepo:     prRepo,
 		userRepo:   userRepo,
 		reviewRepo: reviewRepo,
+		teamRepo:   teamRepo,
+		outboxRepo: outboxRepo,
+		auditRepo:  auditRepo,
+		notifier:   notifier,
 	}
 }
 
 func (s *PullRequestService) Create(ctx context.Context, req *api.PostPullRequestCreateJSONRequestBody) (*models.PullRequest, error) {
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
-	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
-	}
+	ctx, span := tracing.Start(ctx, "PullRequestService.Create")
+	defer span.End()
 
-	defer func() {
-		_ = tx.Rollback(ctx)
-	}()
+	return s.create(ctx, req, nil, nil)
+}
 
-	existsPR, err := s.prRepo.Exists(ctx, tx, req.PullRequestId)
-	if err == nil && existsPR {
-		return nil, apperrors.ErrPullRequestExists
-	}
+// txHook выполняется первым шагом в транзакции операции над PR. Через него обработка вебхука
+// запоминает доставку в той же транзакции, что и само изменение.
+type txHook func(ctx context.Context, tx database.Tx) error
 
-	existsAuthor, err := s.userRepo.Exists(ctx, tx, req.AuthorId)
-	if err == nil && !existsAuthor {
-		return nil, apperrors.ErrNotFound
+func (h txHook) run(ctx context.Context, tx database.Tx) error {
+	if h == nil {
+		return nil
 	}
 
-	candidates, err := s.userRepo.GetActiveTeammates(ctx, tx, req.AuthorId)
+	return h(ctx, tx)
+}
+
+// create создаёт PR и запоминает внешнюю систему source, из которой он пришёл (nil — API).
+func (s *PullRequestService) create(
+	ctx context.Context,
+	req *api.PostPullRequestCreateJSONRequestBody,
+	source *models.Provider,
+	hook txHook,
+) (*models.PullRequest, error) {
+	var (
+		pr   *models.PullRequest
+		team *models.Team
+	)
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		if err := hook.run(ctx, tx); err != nil {
+			return err
+		}
+
+		existsPR, err := s.prRepo.Exists(ctx, tx, req.PullRequestId)
+		if err != nil {
+			return err
+		}
+		if existsPR {
+			return apperrors.ErrPullRequestExists
+		}
+
+		existsAuthor, err := s.userRepo.Exists(ctx, tx, req.AuthorId)
+		if err != nil {
+			return err
+		}
+		if !existsAuthor {
+			return apperrors.ErrNotFound
+		}
+
+		if team, err = s.teamRepo.GetByUserID(ctx, tx, req.AuthorId); err != nil {
+			return err
+		}
+
+		reviewersRequired := team.ReviewersRequired
+		if req.ReviewersRequired != nil {
+			reviewersRequired = *req.ReviewersRequired
+		}
+		if !isValidReviewersRequired(reviewersRequired) {
+			return apperrors.ErrInvalidReviewers
+		}
+
+		reviewers, err := s.pickReviewers(ctx, tx, team, req.AuthorId, nil, reviewersRequired)
+		if err != nil {
+			return err
+		}
+
+		pr = &models.PullRequest{
+			ID:                req.PullRequestId,
+			Title:             req.PullRequestName,
+			AuthorID:          req.AuthorId,
+			Status:            models.StatusOpen,
+			ReviewersRequired: reviewersRequired,
+			AssignedReviewers: reviewers,
+			Reviews:           pendingReviews(req.PullRequestId, reviewers),
+			Source:            source,
+		}
+
+		if err = s.prRepo.Create(ctx, tx, pr); err != nil {
+			return err
+		}
+
+		if err = s.reviewRepo.Assign(ctx, tx, req.PullRequestId, pr.AssignedReviewers...); err != nil {
+			return err
+		}
+
+		err = s.emit(ctx, tx, models.EventPullRequestCreated, pullRequestCreatedData{
+			PullRequestID:     pr.ID,
+			PullRequestName:   pr.Title,
+			AuthorID:          pr.AuthorID,
+			AssignedReviewers: nonNil(pr.AssignedReviewers),
+			Source:            pr.Source,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = s.emitAssigned(ctx, tx, pr.ID, pr.AssignedReviewers); err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, s.auditRepo, tx, models.AuditPullRequestCreated, models.AuditEntityPullRequest, pr.ID,
+			nil, pullRequestAudit(pr))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	pr := &models.PullRequest{
-		ID:                req.PullRequestId,
-		Title:             req.PullRequestName,
-		AuthorID:          req.AuthorId,
-		Status:            models.StatusOpen,
-		AssignedReviewers: chooseRandomReviewers(candidates),
+	metrics.PullRequestCreated(pr.Source)
+	if len(pr.AssignedReviewers) < pr.ReviewersRequired {
+		metrics.NoCandidate("create")
 	}
 
-	if err = s.prRepo.Create(ctx, tx, pr); err != nil {
+	s.notifier.Notify(ctx, team, ChatMessage{
+		Event:           models.EventPullRequestCreated,
+		PullRequestID:   pr.ID,
+		PullRequestName: pr.Title,
+		AuthorID:        pr.AuthorID,
+		Reviewers:       strings.Join(pr.AssignedReviewers, ", "),
+	})
+
+	return pr, nil
+}
+
+// Merge помечает PR как MERGED, повторный вызов возвращает PR без изменений.
+// Если в команде автора включён require_approvals, merge возможен только после APPROVED
+// от всех назначенных ревьюверов.
+func (s *PullRequestService) Merge(ctx context.Context, prID string) (*models.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Merge")
+	defer span.End()
+
+	return s.merge(ctx, prID, true, nil)
+}
+
+// merge без enforceApprovals записывает merge, который уже произошёл во внешней системе:
+// отказ из-за недостающих одобрений только разошёлся бы с ней навсегда.
+func (s *PullRequestService) merge(
+	ctx context.Context,
+	prID string,
+	enforceApprovals bool,
+	hook txHook,
+) (*models.PullRequest, error) {
+	var (
+		pr            *models.PullRequest
+		team          *models.Team
+		alreadyMerged bool
+	)
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		err := hook.run(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if pr, err = s.getWithReviewers(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		switch pr.Status {
+		case models.StatusMerged:
+			alreadyMerged = true
+			return nil
+		case models.StatusClosed:
+			return apperrors.ErrPullRequestClosed
+		}
+
+		if team, err = s.teamRepo.GetByUserID(ctx, tx, pr.AuthorID); err != nil {
+			return err
+		}
+
+		if enforceApprovals && team.RequireApprovals {
+			if err = checkApproved(pr.Reviews); err != nil {
+				return err
+			}
+		}
+
+		before := pullRequestAudit(pr)
+		if err = s.prRepo.UpdateMergeStatus(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		if pr, err = s.getWithReviewers(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		err = writeAudit(ctx, s.auditRepo, tx, models.AuditPullRequestMerged, models.AuditEntityPullRequest, pr.ID,
+			before, pullRequestAudit(pr))
+		if err != nil {
+			return err
+		}
+
+		return s.emit(ctx, tx, models.EventPullRequestMerged, pullRequestMergedData{
+			PullRequestID:   pr.ID,
+			PullRequestName: pr.Title,
+			AuthorID:        pr.AuthorID,
+			MergedAt:        pr.MergedAt,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
+	if alreadyMerged {
+		return pr, nil
+	}
 
-	if err = s.reviewRepo.Assign(ctx, tx, req.PullRequestId, pr.AssignedReviewers...); err != nil {
-		return nil, err
+	metrics.PullRequestMerged()
+
+	s.notifier.Notify(ctx, team, ChatMessage{
+		Event:           models.EventPullRequestMerged,
+		PullRequestID:   pr.ID,
+		PullRequestName: pr.Title,
+		AuthorID:        pr.AuthorID,
+		Reviewers:       strings.Join(pr.AssignedReviewers, ", "),
+	})
+
+	return pr, nil
+}
+
+// SubmitReview сохраняет решение ревьювера (APPROVED или CHANGES_REQUESTED) по открытому PR.
+// Повторная отправка перезаписывает предыдущее решение.
+func (s *PullRequestService) SubmitReview(
+	ctx context.Context,
+	prID, reviewerID string,
+	state models.ReviewState,
+	comment *string,
+) (*models.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.SubmitReview")
+	defer span.End()
+
+	return s.submitReview(ctx, prID, reviewerID, state, comment, nil)
+}
+
+func (s *PullRequestService) submitReview(
+	ctx context.Context,
+	prID, reviewerID string,
+	state models.ReviewState,
+	comment *string,
+	hook txHook,
+) (*models.PullRequest, error) {
+	if state != models.ReviewApproved && state != models.ReviewChangesRequested {
+		return nil, apperrors.ErrInvalidReview
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	var pr *models.PullRequest
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		err := hook.run(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if pr, err = s.prRepo.GetByID(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		switch pr.Status {
+		case models.StatusMerged:
+			return apperrors.ErrAlreadyMerged
+		case models.StatusClosed:
+			return apperrors.ErrPullRequestClosed
+		}
+
+		err = s.reviewRepo.UpdateState(ctx, tx, &models.Review{
+			PullRequestID: prID,
+			ReviewerID:    reviewerID,
+			State:         state,
+			Comment:       comment,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.loadReviews(ctx, tx, pr)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return pr, nil
 }
 
-func (s *PullRequestService) Merge(ctx context.Context, prID string) (*models.PullRequest, error) {
-	if err := s.prRepo.UpdateMergeStatus(ctx, s.db.Pool(), prID); err != nil {
+// Close помечает открытый PR как CLOSED. Ревьюверы при этом замораживаются так же, как после merge.
+// Повторный вызов для закрытого PR возвращает его без изменений.
+func (s *PullRequestService) Close(ctx context.Context, prID string) (*models.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Close")
+	defer span.End()
+
+	return s.setStatus(ctx, prID, s.prRepo.UpdateCloseStatus, nil)
+}
+
+// Reopen возвращает закрытый PR в OPEN с теми же ревьюверами. Для открытого PR ничего не меняет.
+func (s *PullRequestService) Reopen(ctx context.Context, prID string) (*models.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Reopen")
+	defer span.End()
+
+	return s.setStatus(ctx, prID, s.prRepo.Reopen, nil)
+}
+
+// setStatus применяет update (закрытие или повторное открытие) и возвращает PR; смерженный PR не меняется.
+func (s *PullRequestService) setStatus(
+	ctx context.Context,
+	prID string,
+	update func(ctx context.Context, db repository.DBTX, prID string) error,
+	hook txHook,
+) (*models.PullRequest, error) {
+	var pr *models.PullRequest
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		err := hook.run(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if err = update(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		if pr, err = s.getWithReviewers(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		if pr.Status == models.StatusMerged {
+			return apperrors.ErrAlreadyMerged
+		}
+
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	pr, err := s.prRepo.GetByID(ctx, s.db.Pool(), prID)
+	return pr, nil
+}
+
+func (s *PullRequestService) getWithReviewers(ctx context.Context, db repository.DBTX, prID string) (*models.PullRequest, error) {
+	pr, err := s.prRepo.GetByID(ctx, db, prID)
 	if err != nil {
 		return nil, err
 	}
 
-	pr.AssignedReviewers, err = s.reviewRepo.GetReviewersByPR(ctx, s.db.Pool(), prID)
-	if err != nil {
+	if err = s.loadReviews(ctx, db, pr); err != nil {
 		return nil, err
 	}
 
 	return pr, nil
 }
 
-func (s *PullRequestService) Reassign(ctx context.Context, prID, oldUserID string) (*models.PullRequest, string, error) {
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+// loadReviews заполняет ревью PR и список назначенных ревьюверов одним запросом.
+func (s *PullRequestService) loadReviews(ctx context.Context, db repository.DBTX, pr *models.PullRequest) error {
+	reviews, err := s.reviewRepo.GetByPR(ctx, db, pr.ID)
 	if err != nil {
-		return nil, "", err
+		return err
 	}
 
-	defer func() {
-		_ = tx.Rollback(ctx)
-	}()
+	pr.Reviews = reviews
+	pr.AssignedReviewers = make([]string, len(reviews))
+	for i, review := range reviews {
+		pr.AssignedReviewers[i] = review.ReviewerID
+	}
 
-	pr, err := s.prRepo.GetByID(ctx, tx, prID)
+	return nil
+}
+
+func (s *PullRequestService) Reassign(ctx context.Context, prID, oldUserID string) (*models.PullRequest, string, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Reassign")
+	defer span.End()
+
+	var (
+		pr           *models.PullRequest
+		team         *models.Team
+		newReviewers []string
+	)
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		var err error
+		if pr, err = s.prRepo.GetByID(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		if pr.Status == models.StatusMerged {
+			return apperrors.ErrPullRequestMerged
+		}
+		if pr.Status == models.StatusClosed {
+			return apperrors.ErrPullRequestClosed
+		}
+
+		if pr.AssignedReviewers, err = s.reviewRepo.GetReviewersByPR(ctx, tx, prID); err != nil {
+			return err
+		}
+
+		if err = checkAssignedUser(pr.AssignedReviewers, oldUserID); err != nil {
+			return err
+		}
+
+		before := pullRequestAudit(pr)
+
+		if team, err = s.teamRepo.GetByUserID(ctx, tx, pr.AuthorID); err != nil {
+			return err
+		}
+
+		// заменяем ушедшего ревьювера и заодно добираем недостающих до reviewers_required
+		missing := max(1, pr.ReviewersRequired-len(pr.AssignedReviewers)+1)
+
+		newReviewers, err = s.pickReviewers(ctx, tx, team, pr.AuthorID, pr.AssignedReviewers, missing)
+		if err != nil {
+			return err
+		}
+		if len(newReviewers) == 0 {
+			return apperrors.ErrNoCandidate
+		}
+
+		if err = s.reviewRepo.Delete(ctx, tx, prID, oldUserID); err != nil {
+			return err
+		}
+
+		if err = s.reviewRepo.Assign(ctx, tx, prID, newReviewers...); err != nil {
+			return err
+		}
+
+		err = s.emitReassigned(ctx, tx, []models.Reassignment{{
+			PullRequestID: prID,
+			OldReviewers:  []string{oldUserID},
+			NewReviewers:  newReviewers,
+		}})
+		if err != nil {
+			return err
+		}
+
+		if err = s.loadReviews(ctx, tx, pr); err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, s.auditRepo, tx, models.AuditReviewerReassigned, models.AuditEntityPullRequest, pr.ID,
+			before, pullRequestAudit(pr))
+	})
+	if errors.Is(err, apperrors.ErrNoCandidate) {
+		metrics.NoCandidate("reassign")
+	}
 	if err != nil {
 		return nil, "", err
 	}
 
-	if pr.Status == models.StatusMerged {
-		return nil, "", apperrors.ErrPullRequestMerged
+	metrics.ReviewersReassigned("manual", 1)
+
+	s.notifier.Notify(ctx, team, ChatMessage{
+		Event:           models.EventReviewerReassigned,
+		PullRequestID:   pr.ID,
+		PullRequestName: pr.Title,
+		AuthorID:        pr.AuthorID,
+		Reviewers:       strings.Join(newReviewers, ", "),
+		OldReviewers:    oldUserID,
+	})
+
+	return pr, newReviewers[0], nil
+}
+
+func (s *PullRequestService) GetReviewForUser(ctx context.Context, userID string) ([]models.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.GetReviewForUser")
+	defer span.End()
+
+	return s.prRepo.GetAssignedForUser(ctx, s.db.Conn(), userID)
+}
+
+// pickReviewers выбирает до limit ревьюверов из активных участников команды автора
+// по стратегии команды, пропуская тех, кто указан в exclude, и тех, кто упёрся в max_open_reviews.
+// Если подходящих кандидатов меньше limit, возвращаются все, кто есть: нехватку видно
+// в missing_reviewers, как и после деактивации ревьювера в releaseReviewers.
+func (s *PullRequestService) pickReviewers(
+	ctx context.Context,
+	tx database.Tx,
+	team *models.Team,
+	authorID string,
+	exclude []string,
+	limit int,
+) ([]string, error) {
+	teammates, err := s.userRepo.GetActiveTeammates(ctx, tx, authorID)
+	if err != nil {
+		return nil, err
 	}
 
-	if pr.AssignedReviewers, err = s.reviewRepo.GetReviewersByPR(ctx, tx, prID); err != nil {
-		return nil, "", err
+	candidateIDs := excludeUsers(memberIDs(teammates), exclude)
+
+	openReviews, err := s.reviewRepo.CountOpenByReviewers(ctx, tx, candidateIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	if err = checkAssignedUser(pr.AssignedReviewers, oldUserID); err != nil {
-		return nil, "", err
+	available := withCapacity(candidateIDs, reviewLimits(teammates), openReviews)
+	reviewers := selectReviewers(team, available, openReviews, limit)
+
+	if team.ReviewerStrategy == models.StrategyRoundRobin && len(reviewers) > 0 {
+		if err = s.teamRepo.UpdateLastReviewer(ctx, tx, team.ID, *team.LastReviewerID); err != nil {
+			return nil, err
+		}
 	}
 
-	candidates, err := s.userRepo.GetActiveTeammates(ctx, tx, pr.AuthorID)
-	if err != nil {
-		return nil, "", err
+	return reviewers, nil
+}
+
+// selectReviewers применяет стратегию команды к кандидатам. Для round-robin курсор
+// сдвигается в team.LastReviewerID, сохранить его в БД должен вызывающий код.
+func selectReviewers(team *models.Team, candidateIDs []string, openReviews map[string]int, limit int) []string {
+	candidates := make([]ReviewerCandidate, len(candidateIDs))
+	for i, userID := range candidateIDs {
+		candidates[i] = ReviewerCandidate{UserID: userID, OpenReviews: openReviews[userID]}
 	}
 
-	newReviewer, err := changeAvailableReviewer(candidates, pr.AssignedReviewers)
-	if err != nil {
-		return nil, "", err
+	reviewers := newReviewerSelector(team).Select(candidates, limit)
+	if len(reviewers) > 0 {
+		lastReviewerID := reviewers[len(reviewers)-1]
+		team.LastReviewerID = &lastReviewerID
 	}
 
-	if err = s.reviewRepo.Delete(ctx, tx, prID, oldUserID); err != nil {
-		return nil, "", err
+	return reviewers
+}
+
+func memberIDs(members []api.TeamMember) []string {
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member.UserId
 	}
 
-	if err = s.reviewRepo.Assign(ctx, tx, prID, newReviewer); err != nil {
-		return nil, "", err
+	return ids
+}
+
+// reviewLimits возвращает max_open_reviews участников, у которых лимит задан.
+func reviewLimits(members []api.TeamMember) map[string]int {
+	limits := make(map[string]int, len(members))
+	for _, member := range members {
+		if member.MaxOpenReviews != nil {
+			limits[member.UserId] = *member.MaxOpenReviews
+		}
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, "", err
+	return limits
+}
+
+// withCapacity оставляет кандидатов, которые могут взять ещё одно ревью:
+// у них нет max_open_reviews или открытых ревью меньше лимита.
+func withCapacity(candidateIDs []string, limits, openReviews map[string]int) []string {
+	available := make([]string, 0, len(candidateIDs))
+	for _, userID := range candidateIDs {
+		if limit, ok := limits[userID]; ok && openReviews[userID] >= limit {
+			continue
+		}
+		available = append(available, userID)
 	}
 
-	updatedPR, _ := s.prRepo.GetByID(ctx, s.db.Pool(), prID)
-	return updatedPR, newReviewer, nil
+	return available
 }
 
-func (s *PullRequestService) GetReviewForUser(ctx context.Context, userID string) ([]models.PullRequest, error) {
-	return s.prRepo.GetAssignedForUser(ctx, s.db.Pool(), userID)
+func excludeUsers(userIDs []string, exclude []string) []string {
+	excluded := make(map[string]struct{}, len(exclude))
+	for _, userID := range exclude {
+		excluded[userID] = struct{}{}
+	}
+
+	result := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := excluded[userID]; !ok {
+			result = append(result, userID)
+		}
+	}
+
+	return result
 }
 
-func chooseRandomReviewers(candidates []api.TeamMember) []string {
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+func pendingReviews(prID string, reviewerIDs []string) []models.Review {
+	reviews := make([]models.Review, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		reviews[i] = models.Review{PullRequestID: prID, ReviewerID: reviewerID, State: models.ReviewPending}
+	}
+
+	return reviews
+}
 
-	reviewers := make([]string, min(2, len(candidates)))
-	for i := 0; i < len(reviewers); i++ {
-		reviewers[i] = candidates[i].UserId
+// checkApproved возвращает ErrNotApproved со списком ревьюверов, которые ещё не одобрили PR.
+func checkApproved(reviews []models.Review) error {
+	var waiting []string
+	for _, review := range reviews {
+		if review.State != models.ReviewApproved {
+			waiting = append(waiting, fmt.Sprintf("%s (%s)", review.ReviewerID, review.State))
+		}
+	}
+	if len(waiting) > 0 {
+		return fmt.Errorf("%w: waiting for %s", apperrors.ErrNotApproved, strings.Join(waiting, ", "))
 	}
 
-	return reviewers
+	return nil
+}
+
+func isValidReviewersRequired(reviewersRequired int) bool {
+	return reviewersRequired >= 1 && reviewersRequired <= models.MaxReviewersRequired
 }
 
 func checkAssignedUser(assignedReviewers []string, oldUserID string) error {
@@ -184,23 +654,3 @@ func checkAssignedUser(assignedReviewers []string, oldUserID string) error {
 
 	return nil
 }
-
-func changeAvailableReviewer(candidates []api.TeamMember, assignedReviewers []string) (string, error) {
-	available := make(map[string]struct{}, len(candidates) + 2)
-	for _, candidate := range candidates {
-		available[candidate.UserId] = struct{}{}
-	}
-	for _, currReviewer := range assignedReviewers {
-		delete(available, currReviewer)
-	}
-
-	if len(available) == 0 {
-		return "", apperrors.ErrNoCandidate
-	}
-
-	for newReviewer := range available { // ха-ха-ха, я пользуюсь тем, что в Go случайный обход мапы
-		return newReviewer, nil
-	}
-
-	return "", nil // заглушка, чтобы gopls не ругался
-}
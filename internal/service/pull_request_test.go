@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository/memory"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+)
+
+func newTestService(t *testing.T, members ...api.TeamMember) *Service {
+	t.Helper()
+
+	svc := NewService(memory.NewStore(), memory.NewRepository(), &config.Config{})
+	_, err := svc.TeamService.CreateTeam(context.Background(), api.Team{TeamName: "backend", Members: members})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	return svc
+}
+
+func member(id string, maxOpenReviews *int) api.TeamMember {
+	return api.TeamMember{UserId: id, Username: id, IsActive: true, MaxOpenReviews: maxOpenReviews}
+}
+
+func createPR(t *testing.T, svc *Service, id, authorID string) []string {
+	t.Helper()
+
+	pr, err := svc.PullRequestService.Create(context.Background(), &api.PostPullRequestCreateJSONRequestBody{
+		PullRequestId:   id,
+		PullRequestName: id,
+		AuthorId:        authorID,
+	})
+	if err != nil {
+		t.Fatalf("create %s: %v", id, err)
+	}
+	if pr.ReviewersRequired != 2 {
+		t.Fatalf("%s reviewers_required = %d, want team default 2", id, pr.ReviewersRequired)
+	}
+
+	return pr.AssignedReviewers
+}
+
+func TestCreateAssignsReviewersThatFit(t *testing.T) {
+	one := 1
+
+	tests := []struct {
+		name    string
+		members []api.TeamMember
+		// before — PR, которые u1 открывает заранее, чтобы занять лимиты
+		before int
+		want   []string
+	}{
+		{name: "enough candidates", members: []api.TeamMember{member("u2", nil), member("u3", nil)}, want: []string{"u2", "u3"}},
+		{name: "no teammates"},
+		{name: "one teammate", members: []api.TeamMember{member("u2", nil)}, want: []string{"u2"}},
+		{
+			name:    "all teammates at capacity",
+			members: []api.TeamMember{member("u2", &one), member("u3", &one)},
+			before:  1,
+		},
+		{
+			name:    "some teammates at capacity",
+			members: []api.TeamMember{member("u2", &one), member("u3", nil)},
+			before:  1,
+			want:    []string{"u3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t, append([]api.TeamMember{member("u1", nil)}, tt.members...)...)
+			for i := range tt.before {
+				createPR(t, svc, fmt.Sprintf("warmup-%d", i), "u1")
+			}
+
+			got := createPR(t, svc, "pr-1", "u1")
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("assigned reviewers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeactivationReportsShortfallLikeCreate(t *testing.T) {
+	one := 1
+	svc := newTestService(t, member("u1", nil), member("u2", nil), member("u3", &one))
+
+	if got := createPR(t, svc, "pr-1", "u1"); len(got) != 2 {
+		t.Fatalf("assigned reviewers = %v, want u2 and u3", got)
+	}
+
+	// u3 занят pr-1, u2 уходит: замены нет, PR остаётся с u3 и нехваткой в один голос
+	result, err := svc.UserService.SetIsActive(context.Background(), "u2", false, true)
+	if err != nil {
+		t.Fatalf("deactivate u2: %v", err)
+	}
+	if len(result.Reassignments) != 1 {
+		t.Fatalf("reassignments = %+v, want one for pr-1", result.Reassignments)
+	}
+	if r := result.Reassignments[0]; len(r.NewReviewers) != 0 || r.MissingReviewers != 1 {
+		t.Errorf("reassignment = %+v, want no new reviewers and 1 missing", r)
+	}
+
+	// новый PR тоже создаётся без ожидания свободных ревьюверов, нехватка видна так же
+	if got := createPR(t, svc, "pr-2", "u1"); len(got) != 0 {
+		t.Errorf("pr-2 assigned reviewers = %v, want none: u3 is at capacity", got)
+	}
+}
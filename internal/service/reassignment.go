@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+)
+
+// releaseReviewers снимает пользователей reviewerIDs со всех открытых PR и подбирает им замену
+// по тем же правилам, что и Reassign (включая max_open_reviews). Если кандидатов не хватает,
+// ревьювер просто снимается, а нехватка попадает в MissingReviewers — так же, как при создании PR.
+// Каждое переназначение пишется в аудит так же, как Reassign.
+// Все выборки и записи делаются пачкой, чтобы время не зависело от числа затронутых PR.
+func (s *PullRequestService) releaseReviewers(
+	ctx context.Context,
+	tx database.Tx,
+	reviewerIDs []string,
+) ([]models.Reassignment, error) {
+	if len(reviewerIDs) == 0 {
+		return nil, nil
+	}
+
+	prs, err := s.prRepo.GetOpenReviewedBy(ctx, tx, reviewerIDs)
+	if err != nil || len(prs) == 0 {
+		return nil, err
+	}
+
+	prIDs := make([]string, len(prs))
+	authorIDs := make([]string, len(prs))
+	for i, pr := range prs {
+		prIDs[i] = pr.ID
+		authorIDs[i] = pr.AuthorID
+	}
+
+	teams, err := s.teamRepo.GetByUserIDs(ctx, tx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	teamIDs := make([]int, 0, len(teams))
+	seenTeams := make(map[int]*models.Team, len(teams))
+	for _, team := range teams {
+		if _, ok := seenTeams[team.ID]; !ok {
+			seenTeams[team.ID] = team
+			teamIDs = append(teamIDs, team.ID)
+		}
+	}
+
+	members, err := s.userRepo.GetActiveByTeamIDs(ctx, tx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidateIDs []string
+	memberIDs := make(map[int][]string, len(members))
+	limits := make(map[string]int)
+	for teamID, users := range members {
+		for _, user := range users {
+			memberIDs[teamID] = append(memberIDs[teamID], user.ID)
+			if user.MaxOpenReviews != nil {
+				limits[user.ID] = *user.MaxOpenReviews
+			}
+		}
+		candidateIDs = append(candidateIDs, memberIDs[teamID]...)
+	}
+
+	openReviews, err := s.reviewRepo.CountOpenByReviewers(ctx, tx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	released := make(map[string]struct{}, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		released[id] = struct{}{}
+	}
+
+	var added []models.Review
+	reassignments := make([]models.Reassignment, 0, len(prs))
+	audit := make([]models.AuditEntry, 0, len(prs))
+	roundRobinTeams := make(map[int]*models.Team)
+	for _, pr := range prs {
+		team := teams[pr.AuthorID]
+
+		var oldReviewers, remaining []string
+		for _, reviewerID := range pr.AssignedReviewers {
+			if _, ok := released[reviewerID]; ok {
+				oldReviewers = append(oldReviewers, reviewerID)
+			} else {
+				remaining = append(remaining, reviewerID)
+			}
+		}
+
+		need := max(len(oldReviewers), pr.ReviewersRequired-len(remaining))
+		candidates := excludeUsers(memberIDs[team.ID], append([]string{pr.AuthorID}, pr.AssignedReviewers...))
+		// счётчики openReviews растут по ходу цикла, так что лимит учитывает и только что сделанные назначения
+		candidates = withCapacity(candidates, limits, openReviews)
+		newReviewers := selectReviewers(team, candidates, openReviews, need)
+
+		for _, reviewerID := range newReviewers {
+			openReviews[reviewerID]++
+			added = append(added, models.Review{PullRequestID: pr.ID, ReviewerID: reviewerID})
+		}
+		if team.ReviewerStrategy == models.StrategyRoundRobin && len(newReviewers) > 0 {
+			roundRobinTeams[team.ID] = team
+		}
+
+		reassignments = append(reassignments, models.Reassignment{
+			PullRequestID:    pr.ID,
+			OldReviewers:     oldReviewers,
+			NewReviewers:     newReviewers,
+			MissingReviewers: max(0, pr.ReviewersRequired-len(remaining)-len(newReviewers)),
+		})
+
+		before := pullRequestAudit(&pr)
+		after := *before
+		after.AssignedReviewers = append(nonNil(remaining), newReviewers...)
+		entry, err := newAuditEntry(ctx, models.AuditReviewerReassigned, models.AuditEntityPullRequest, pr.ID, before, &after)
+		if err != nil {
+			return nil, err
+		}
+		audit = append(audit, *entry)
+	}
+
+	if err = s.reviewRepo.DeleteByReviewers(ctx, tx, prIDs, reviewerIDs); err != nil {
+		return nil, err
+	}
+
+	if err = s.reviewRepo.AssignBatch(ctx, tx, added); err != nil {
+		return nil, err
+	}
+
+	for _, team := range roundRobinTeams {
+		if err = s.teamRepo.UpdateLastReviewer(ctx, tx, team.ID, *team.LastReviewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.auditRepo.AddBatch(ctx, tx, audit); err != nil {
+		return nil, err
+	}
+
+	if err = s.emitReassigned(ctx, tx, reassignments); err != nil {
+		return nil, err
+	}
+
+	return reassignments, nil
+}
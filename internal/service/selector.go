@@ -0,0 +1,120 @@
+package service
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+)
+
+type ReviewerCandidate struct {
+	UserID      string
+	OpenReviews int
+}
+
+// ReviewerSelector выбирает до limit ревьюверов из списка кандидатов.
+// Кандидаты уже отфильтрованы: только активные участники команды без автора и текущих ревьюверов.
+type ReviewerSelector interface {
+	Select(candidates []ReviewerCandidate, limit int) []string
+}
+
+func newReviewerSelector(team *models.Team) ReviewerSelector {
+	switch team.ReviewerStrategy {
+	case models.StrategyRandom:
+		return randomSelector{}
+	case models.StrategyRoundRobin:
+		return roundRobinSelector{lastReviewerID: team.LastReviewerID}
+	case models.StrategyWeighted:
+		return weightedSelector{}
+	default:
+		return leastLoadedSelector{}
+	}
+}
+
+func isKnownStrategy(strategy models.ReviewerStrategy) bool {
+	switch strategy {
+	case models.StrategyRandom, models.StrategyRoundRobin, models.StrategyLeastLoaded, models.StrategyWeighted:
+		return true
+	default:
+		return false
+	}
+}
+
+type randomSelector struct{}
+
+func (randomSelector) Select(candidates []ReviewerCandidate, limit int) []string {
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	return takeIDs(candidates, limit)
+}
+
+// leastLoadedSelector отдаёт предпочтение кандидатам с наименьшим числом открытых ревью,
+// при равной загрузке выбор случайный.
+type leastLoadedSelector struct{}
+
+func (leastLoadedSelector) Select(candidates []ReviewerCandidate, limit int) []string {
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].OpenReviews < candidates[j].OpenReviews
+	})
+
+	return takeIDs(candidates, limit)
+}
+
+// roundRobinSelector обходит участников команды по кругу в порядке user_id,
+// начиная со следующего после последнего назначенного.
+type roundRobinSelector struct {
+	lastReviewerID *string
+}
+
+func (s roundRobinSelector) Select(candidates []ReviewerCandidate, limit int) []string {
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].UserID < candidates[j].UserID
+	})
+
+	start := 0
+	if s.lastReviewerID != nil {
+		start = sort.Search(len(candidates), func(i int) bool {
+			return candidates[i].UserID > *s.lastReviewerID
+		})
+	}
+
+	reviewers := make([]string, min(limit, len(candidates)))
+	for i := 0; i < len(reviewers); i++ {
+		reviewers[i] = candidates[(start+i)%len(candidates)].UserID
+	}
+
+	return reviewers
+}
+
+// weightedSelector выбирает случайно, но с весом 1/(1+открытые ревью),
+// так что загруженные участники попадают на ревью реже, но не исключаются совсем.
+type weightedSelector struct{}
+
+func (weightedSelector) Select(candidates []ReviewerCandidate, limit int) []string {
+	// взвешенная выборка без возвращения (Efraimidis–Spirakis): берём наименьшие ключи -ln(u)/w
+	keys := make(map[string]float64, len(candidates))
+	for _, candidate := range candidates {
+		weight := 1 / float64(1+candidate.OpenReviews)
+		keys[candidate.UserID] = -math.Log(1-rand.Float64()) / weight
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return keys[candidates[i].UserID] < keys[candidates[j].UserID]
+	})
+
+	return takeIDs(candidates, limit)
+}
+
+func takeIDs(candidates []ReviewerCandidate, limit int) []string {
+	reviewers := make([]string, min(limit, len(candidates)))
+	for i := 0; i < len(reviewers); i++ {
+		reviewers[i] = candidates[i].UserID
+	}
+
+	return reviewers
+}
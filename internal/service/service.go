@@ -1,6 +1,7 @@
 package service
 
 import (
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
 	"github.com/AntonTsoy/review-pull-request-service/internal/database"
 	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
 )
@@ -9,12 +10,28 @@ type Service struct {
 	TeamService *TeamService
 	UserService *UserService
 	PullRequestService *PullRequestService
+	StatsService *StatsService
+	WebhookService *WebhookService
+	SubscriptionService *SubscriptionService
+	EventDispatcher *EventDispatcher
+	AuthService *AuthService
+	AuditService *AuditService
+	HealthService *HealthService
 }
 
-func NewService(db *database.Database, repo *repository.Repository) *Service {
+func NewService(db database.Storage, repo *repository.Repository, cfg *config.Config) *Service {
+	prService := newPullRequestService(db, repo.PullRequestRepository, repo.UserRepository, repo.ReviewRepository, repo.TeamRepository, repo.OutboxRepository, repo.AuditRepository, newChatNotifier(cfg))
+
 	return &Service{
-		TeamService: newTeamService(db, repo.TeamRepository, repo.UserRepository),
-		UserService: newUserService(db, repo.UserRepository, repo.TeamRepository),
-		PullRequestService: newPullRequestService(db, repo.PullRequestRepository, repo.UserRepository, repo.ReviewRepository),
+		TeamService: newTeamService(db, repo.TeamRepository, repo.UserRepository, repo.AuditRepository, prService),
+		UserService: newUserService(db, repo.UserRepository, repo.TeamRepository, repo.PullRequestRepository, repo.AbsenceRepository, repo.AuditRepository, prService),
+		PullRequestService: prService,
+		StatsService: newStatsService(db, repo.StatsRepository),
+		WebhookService: newWebhookService(db, repo.LoginRepository, repo.DeliveryRepository, repo.UserRepository, prService),
+		SubscriptionService: newSubscriptionService(db, repo.SubscriptionRepository, repo.EventDeliveryRepository),
+		EventDispatcher: newEventDispatcher(db, repo.OutboxRepository, repo.SubscriptionRepository, repo.EventDeliveryRepository, cfg),
+		AuthService: newAuthService(db, repo.TokenRepository, cfg.AdminToken),
+		AuditService: newAuditService(db, repo.AuditRepository),
+		HealthService: newHealthService(db, cfg),
 	}
 }
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+)
+
+type StatsService struct {
+	db        database.Storage
+	statsRepo repository.StatsRepository
+}
+
+func newStatsService(db database.Storage, statsRepo repository.StatsRepository) *StatsService {
+	return &StatsService{
+		db:        db,
+		statsRepo: statsRepo,
+	}
+}
+
+func (s *StatsService) GetAssignments(ctx context.Context, filter models.StatsFilter) ([]models.UserAssignmentStats, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.GetAssignments")
+	defer span.End()
+
+	filter, err := normalizeStatsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.statsRepo.GetAssignments(ctx, s.db.Conn(), filter)
+}
+
+func (s *StatsService) GetPullRequests(ctx context.Context, filter models.StatsFilter) ([]models.TeamPullRequestStats, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.GetPullRequests")
+	defer span.End()
+
+	filter, err := normalizeStatsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.statsRepo.GetPullRequests(ctx, s.db.Conn(), filter)
+}
+
+// normalizeStatsFilter приводит границы периода к UTC: created_at хранится как TIMESTAMP без зоны.
+func normalizeStatsFilter(filter models.StatsFilter) (models.StatsFilter, error) {
+	if filter.From != nil {
+		from := filter.From.UTC()
+		filter.From = &from
+	}
+	if filter.To != nil {
+		to := filter.To.UTC()
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, apperrors.ErrInvalidPeriod
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+)
+
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 500
+)
+
+type SubscriptionService struct {
+	db               database.Storage
+	subscriptionRepo repository.SubscriptionRepository
+	deliveryRepo     repository.EventDeliveryRepository
+}
+
+func newSubscriptionService(
+	db database.Storage,
+	subscriptionRepo repository.SubscriptionRepository,
+	deliveryRepo repository.EventDeliveryRepository,
+) *SubscriptionService {
+	return &SubscriptionService{
+		db:               db,
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+	}
+}
+
+// Create заводит подписку. Если секрет не задан, он генерируется; подписчик узнаёт его
+// только из ответа на создание.
+func (s *SubscriptionService) Create(ctx context.Context, sub *models.Subscription) (*models.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "SubscriptionService.Create")
+	defer span.End()
+
+	sub.Events = uniqueEvents(sub.Events)
+	if err := validateSubscription(sub); err != nil {
+		return nil, err
+	}
+
+	if sub.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		sub.Secret = secret
+	}
+
+	var created *models.Subscription
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		id, err := s.subscriptionRepo.Create(ctx, tx, sub)
+		if err != nil {
+			return err
+		}
+
+		created, err = s.subscriptionRepo.GetByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *SubscriptionService) List(ctx context.Context) ([]models.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "SubscriptionService.List")
+	defer span.End()
+
+	return s.subscriptionRepo.List(ctx, s.db.Conn())
+}
+
+// Update меняет только переданные поля. Выключенная подписка новых доставок не получает,
+// а уже созданные ждут, пока её включат снова.
+func (s *SubscriptionService) Update(
+	ctx context.Context,
+	id int,
+	endpoint *string,
+	events *[]models.EventType,
+	isActive *bool,
+) (*models.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "SubscriptionService.Update")
+	defer span.End()
+
+	if endpoint == nil && events == nil && isActive == nil {
+		return nil, apperrors.ErrNothingToUpdate
+	}
+
+	var sub *models.Subscription
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		var err error
+		if sub, err = s.subscriptionRepo.GetByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		if endpoint != nil {
+			sub.URL = *endpoint
+		}
+		if events != nil {
+			sub.Events = uniqueEvents(*events)
+		}
+		if isActive != nil {
+			sub.IsActive = *isActive
+		}
+
+		if err = validateSubscription(sub); err != nil {
+			return err
+		}
+
+		if err = s.subscriptionRepo.Update(ctx, tx, sub); err != nil {
+			return err
+		}
+
+		sub, err = s.subscriptionRepo.GetByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// Delete удаляет подписку вместе с её журналом доставок.
+func (s *SubscriptionService) Delete(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "SubscriptionService.Delete")
+	defer span.End()
+
+	return s.subscriptionRepo.Delete(ctx, s.db.Conn(), id)
+}
+
+// GetDeliveries возвращает журнал доставок подписки, новые первыми.
+func (s *SubscriptionService) GetDeliveries(ctx context.Context, subscriptionID int, limit *int) ([]models.EventDelivery, error) {
+	ctx, span := tracing.Start(ctx, "SubscriptionService.GetDeliveries")
+	defer span.End()
+
+	n := defaultDeliveriesLimit
+	if limit != nil {
+		n = min(max(*limit, 1), maxDeliveriesLimit)
+	}
+
+	if _, err := s.subscriptionRepo.GetByID(ctx, s.db.Conn(), subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return s.deliveryRepo.GetBySubscription(ctx, s.db.Conn(), subscriptionID, n)
+}
+
+func validateSubscription(sub *models.Subscription) error {
+	if !isHTTPURL(sub.URL) {
+		return apperrors.ErrInvalidURL
+	}
+
+	if len(sub.Events) == 0 {
+		return apperrors.ErrNoEvents
+	}
+	for _, event := range sub.Events {
+		if !isKnownEvent(event) {
+			return fmt.Errorf("%w: %q", apperrors.ErrUnknownEvent, event)
+		}
+	}
+
+	return nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func uniqueEvents(events []models.EventType) []models.EventType {
+	return slices.Compact(slices.Sorted(slices.Values(events)))
+}
+
+func isKnownEvent(event models.EventType) bool {
+	switch event {
+	case models.EventPullRequestCreated,
+		models.EventReviewerAssigned,
+		models.EventReviewerReassigned,
+		models.EventPullRequestMerged:
+		return true
+	default:
+		return false
+	}
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generate subscription secret: %w", err)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -2,83 +2,304 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
 	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
 	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/metrics"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
 	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
-
-	"github.com/jackc/pgx/v5"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
 )
 
 type TeamService struct {
-	db       *database.Database
-	teamRepo *repository.TeamRepository
-	userRepo *repository.UserRepository
+	db        database.Storage
+	teamRepo  repository.TeamRepository
+	userRepo  repository.UserRepository
+	auditRepo repository.AuditRepository
+	prService *PullRequestService
 }
 
-func newTeamService(db *database.Database, teamRepo *repository.TeamRepository, userRepo *repository.UserRepository) *TeamService {
+func newTeamService(
+	db database.Storage,
+	teamRepo repository.TeamRepository,
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditRepository,
+	prService *PullRequestService,
+) *TeamService {
 	return &TeamService{
-		db:       db,
-		teamRepo: teamRepo,
-		userRepo: userRepo,
+		db:        db,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+		prService: prService,
 	}
 }
 
-func (s *TeamService) CreateTeam(ctx context.Context, team api.Team) error {
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
+func (s *TeamService) CreateTeam(ctx context.Context, team api.Team) (*api.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.CreateTeam")
+	defer span.End()
 
-	defer func() {
-		_ = tx.Rollback(ctx)
-	}()
+	strategy := models.StrategyLeastLoaded
+	if team.ReviewerStrategy != nil {
+		strategy = string(*team.ReviewerStrategy)
+	}
+	if !isKnownStrategy(strategy) {
+		return nil, apperrors.ErrUnknownStrategy
+	}
 
-	teamID, err := s.teamRepo.GetByName(ctx, tx, team.TeamName)
-	if err == nil {
-		return apperrors.ErrTeamExists
+	reviewersRequired := models.DefaultReviewersRequired
+	if team.ReviewersRequired != nil {
+		reviewersRequired = *team.ReviewersRequired
+	}
+	if !isValidReviewersRequired(reviewersRequired) {
+		return nil, apperrors.ErrInvalidReviewers
 	}
-	_ = teamID
 
-	teamID, err = s.teamRepo.Create(ctx, tx, team.TeamName)
+	chatWebhookURL, err := normalizeChatWebhookURL(team.ChatWebhookUrl)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for _, member := range team.Members {
-		var flag bool
-		flag, err = s.userRepo.Exists(ctx, tx, member.UserId)
-		if err == nil && flag {
-			if err = s.userRepo.Update(ctx, tx, teamID, &member); err != nil {
-				return err
-			}
-			continue
+	apiStrategy := api.ReviewerStrategy(strategy)
+	requireApprovals := team.RequireApprovals != nil && *team.RequireApprovals
+	team.ReviewerStrategy = &apiStrategy
+	team.ReviewersRequired = &reviewersRequired
+	team.RequireApprovals = &requireApprovals
+	team.ChatWebhookUrl = chatWebhookURL
+
+	err = database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		_, err := s.teamRepo.GetByName(ctx, tx, team.TeamName)
+		if err == nil {
+			return apperrors.ErrTeamExists
+		}
+		if !errors.Is(err, apperrors.ErrNotFound) {
+			return err
 		}
 
-		if err = s.userRepo.Create(ctx, tx, teamID, &member); err != nil {
+		teamID, err := s.teamRepo.Create(ctx, tx, &models.Team{
+			Name:              team.TeamName,
+			ReviewerStrategy:  strategy,
+			ReviewersRequired: reviewersRequired,
+			RequireApprovals:  requireApprovals,
+			ChatWebhookURL:    chatWebhookURL,
+		})
+		if err != nil {
 			return err
 		}
-	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		for _, member := range team.Members {
+			exists, err := s.userRepo.Exists(ctx, tx, member.UserId)
+			if err != nil {
+				return err
+			}
+
+			if exists {
+				err = s.userRepo.Update(ctx, tx, teamID, &member)
+			} else {
+				err = s.userRepo.Create(ctx, tx, teamID, &member)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return writeAudit(ctx, s.auditRepo, tx, models.AuditTeamCreated, models.AuditEntityTeam, team.TeamName, nil, team)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &team, nil
 }
 
-func (s *TeamService) GetTeam(ctx context.Context, teamName string) ([]api.TeamMember, error) {
-	teamID, err := s.teamRepo.GetByName(ctx, s.db.Pool(), teamName)
+func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*api.Team, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeam")
+	defer span.End()
+
+	team, err := s.teamRepo.GetByName(ctx, s.db.Conn(), teamName)
 	if err != nil {
 		return nil, err
 	}
 
-	members, err := s.userRepo.GetByTeamID(ctx, s.db.Pool(), teamID)
+	members, err := s.userRepo.GetByTeamID(ctx, s.db.Conn(), team.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	return members, nil
+	strategy := api.ReviewerStrategy(team.ReviewerStrategy)
+
+	return &api.Team{
+		TeamName:          team.Name,
+		ReviewerStrategy:  &strategy,
+		ReviewersRequired: &team.ReviewersRequired,
+		RequireApprovals:  &team.RequireApprovals,
+		ChatWebhookUrl:    team.ChatWebhookURL,
+		Members:           members,
+	}, nil
+}
+
+func (s *TeamService) UpdateSettings(ctx context.Context, settings api.TeamSettings) (*api.TeamSettings, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.UpdateSettings")
+	defer span.End()
+
+	team, err := s.teamRepo.GetByName(ctx, s.db.Conn(), settings.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.ReviewerStrategy != nil {
+		team.ReviewerStrategy = string(*settings.ReviewerStrategy)
+	}
+	if !isKnownStrategy(team.ReviewerStrategy) {
+		return nil, apperrors.ErrUnknownStrategy
+	}
+
+	if settings.ReviewersRequired != nil {
+		team.ReviewersRequired = *settings.ReviewersRequired
+	}
+	if !isValidReviewersRequired(team.ReviewersRequired) {
+		return nil, apperrors.ErrInvalidReviewers
+	}
+
+	if settings.RequireApprovals != nil {
+		team.RequireApprovals = *settings.RequireApprovals
+	}
+
+	if settings.ChatWebhookUrl != nil {
+		if team.ChatWebhookURL, err = normalizeChatWebhookURL(settings.ChatWebhookUrl); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.teamRepo.UpdateSettings(ctx, s.db.Conn(), team); err != nil {
+		return nil, err
+	}
+
+	strategy := api.ReviewerStrategy(team.ReviewerStrategy)
+
+	return &api.TeamSettings{
+		TeamName:          team.Name,
+		ReviewerStrategy:  &strategy,
+		ReviewersRequired: &team.ReviewersRequired,
+		RequireApprovals:  &team.RequireApprovals,
+		ChatWebhookUrl:    team.ChatWebhookURL,
+	}, nil
+}
+
+// DeactivateMembers деактивирует участников команды (всех, если userIDs == nil) и в той же
+// транзакции переназначает их открытые ревью. Каждому деактивированному пишется запись аудита,
+// как в UserService.SetIsActive.
+func (s *TeamService) DeactivateMembers(
+	ctx context.Context,
+	teamName string,
+	userIDs *[]string,
+) ([]string, []models.Reassignment, error) {
+	ctx, span := tracing.Start(ctx, "TeamService.DeactivateMembers")
+	defer span.End()
+
+	var ids []string
+	if userIDs != nil {
+		ids = append([]string{}, *userIDs...)
+	}
+
+	var (
+		deactivated   []string
+		reassignments []models.Reassignment
+	)
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		team, err := s.teamRepo.GetByName(ctx, tx, teamName)
+		if err != nil {
+			return err
+		}
+
+		members, err := s.userRepo.GetByTeamID(ctx, tx, team.ID)
+		if err != nil {
+			return err
+		}
+
+		if deactivated, err = s.userRepo.DeactivateTeamMembers(ctx, tx, team.ID, ids); err != nil {
+			return err
+		}
+
+		if reassignments, err = s.prService.releaseReviewers(ctx, tx, deactivated); err != nil {
+			return err
+		}
+
+		return s.auditDeactivation(ctx, tx, teamName, members, deactivated, reassignments)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metrics.ReviewersReassigned("deactivation", len(reassignments))
+
+	return deactivated, reassignments, nil
+}
+
+// auditDeactivation пишет по записи AuditUserActivityChanged на каждого из deactivated. Открытые
+// ревью до деактивации — это ровно PR из reassignments, где пользователь был снят.
+func (s *TeamService) auditDeactivation(
+	ctx context.Context,
+	tx database.Tx,
+	teamName string,
+	members []api.TeamMember,
+	deactivated []string,
+	reassignments []models.Reassignment,
+) error {
+	released := make(map[string][]models.Reassignment, len(deactivated))
+	for _, r := range reassignments {
+		for _, userID := range r.OldReviewers {
+			released[userID] = append(released[userID], r)
+		}
+	}
+
+	byID := make(map[string]api.TeamMember, len(members))
+	for _, member := range members {
+		byID[member.UserId] = member
+	}
+
+	entries := make([]models.AuditEntry, 0, len(deactivated))
+	for _, userID := range deactivated {
+		member := byID[userID]
+		userReassignments := released[userID]
+
+		reviewsBefore := make([]string, len(userReassignments))
+		for i, r := range userReassignments {
+			reviewsBefore[i] = r.PullRequestID
+		}
+
+		before := &api.User{
+			UserId:         member.UserId,
+			Username:       member.Username,
+			IsActive:       member.IsActive,
+			TeamName:       teamName,
+			MaxOpenReviews: member.MaxOpenReviews,
+		}
+		after := *before
+		after.IsActive = false
+
+		entry, err := newAuditEntry(ctx, models.AuditUserActivityChanged, models.AuditEntityUser, userID,
+			userActivityAudit(before, reviewsBefore, nil),
+			userActivityAudit(&after, nil, userReassignments))
+		if err != nil {
+			return err
+		}
+		entries = append(entries, *entry)
+	}
+
+	return s.auditRepo.AddBatch(ctx, tx, entries)
+}
+
+// normalizeChatWebhookURL проверяет адрес чата команды; пустая строка означает «без уведомлений».
+func normalizeChatWebhookURL(raw *string) (*string, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+	if !isHTTPURL(*raw) {
+		return nil, apperrors.ErrInvalidURL
+	}
+
+	return raw, nil
 }
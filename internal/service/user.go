@@ -3,40 +3,208 @@ package service
 import (
 	"context"
 
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
 	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/metrics"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
 	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
 )
 
 type UserService struct {
-	db       *database.Database
-	userRepo *repository.UserRepository
-	teamRepo *repository.TeamRepository
+	db          database.Storage
+	userRepo    repository.UserRepository
+	teamRepo    repository.TeamRepository
+	prRepo      repository.PullRequestRepository
+	absenceRepo repository.AbsenceRepository
+	auditRepo   repository.AuditRepository
+	prService   *PullRequestService
 }
 
-func newUserService(db *database.Database, userRepo *repository.UserRepository, teamRepo *repository.TeamRepository) *UserService {
+func newUserService(
+	db database.Storage,
+	userRepo repository.UserRepository,
+	teamRepo repository.TeamRepository,
+	prRepo repository.PullRequestRepository,
+	absenceRepo repository.AbsenceRepository,
+	auditRepo repository.AuditRepository,
+	prService *PullRequestService,
+) *UserService {
 	return &UserService{
-		db:       db,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		db:          db,
+		userRepo:    userRepo,
+		teamRepo:    teamRepo,
+		prRepo:      prRepo,
+		absenceRepo: absenceRepo,
+		auditRepo:   auditRepo,
+		prService:   prService,
 	}
 }
 
-func (s *UserService) SetIsActive(ctx context.Context, userID string, active bool) (*api.User, error) {
-	user, err := s.userRepo.UpdateIsActive(ctx, s.db.Pool(), userID, active)
+type SetIsActiveResult struct {
+	User *api.User
+	// Reassignments заполняется, если открытые ревью деактивированного пользователя переназначены.
+	Reassignments []models.Reassignment
+	// PendingReviews заполняется, если переназначение отключено: открытые PR, где он остался ревьювером.
+	PendingReviews []models.PullRequest
+}
+
+// SetIsActive меняет флаг активности. При деактивации открытые ревью пользователя в той же
+// транзакции переназначаются на других участников, либо (reassign == false) только возвращаются в ответе.
+func (s *UserService) SetIsActive(ctx context.Context, userID string, active, reassign bool) (*SetIsActiveResult, error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetIsActive")
+	defer span.End()
+
+	var result *SetIsActiveResult
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		userBefore, err := s.userRepo.GetByID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		reviewsBefore, err := s.getOpenReviews(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userRepo.UpdateIsActive(ctx, tx, userID, active)
+		if err != nil {
+			return err
+		}
+
+		teamName, err := s.teamRepo.GetByID(ctx, tx, user.TeamID)
+		if err != nil {
+			return err
+		}
+
+		result = &SetIsActiveResult{User: toAPIUser(user, teamName)}
+
+		if !active {
+			if reassign {
+				result.Reassignments, err = s.prService.releaseReviewers(ctx, tx, []string{user.ID})
+			} else {
+				result.PendingReviews, err = s.getOpenReviews(ctx, tx, user.ID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		reviewsAfter, err := s.getOpenReviews(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, s.auditRepo, tx, models.AuditUserActivityChanged, models.AuditEntityUser, user.ID,
+			userActivityAudit(toAPIUser(userBefore, teamName), pullRequestIDs(reviewsBefore), nil),
+			userActivityAudit(result.User, pullRequestIDs(reviewsAfter), result.Reassignments))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ReviewersReassigned("deactivation", len(result.Reassignments))
+
+	return result, nil
+}
+
+// UpdateUser меняет имя и/или лимит открытых ревью. maxOpenReviews == 0 снимает лимит.
+// Уже назначенные ревью лимит не трогает: он влияет только на новые назначения.
+func (s *UserService) UpdateUser(ctx context.Context, userID string, username *string, maxOpenReviews *int) (*api.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
+	if username == nil && maxOpenReviews == nil {
+		return nil, apperrors.ErrNothingToUpdate
+	}
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return nil, apperrors.ErrInvalidReviewCap
+	}
+
+	user, err := s.userRepo.UpdateProfile(ctx, s.db.Conn(), userID, username, maxOpenReviews)
+	if err != nil {
+		return nil, err
+	}
+
+	teamName, err := s.teamRepo.GetByID(ctx, s.db.Conn(), user.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAPIUser(user, teamName), nil
+}
+
+func (s *UserService) getOpenReviews(ctx context.Context, tx database.Tx, userID string) ([]models.PullRequest, error) {
+	prs, err := s.prRepo.GetAssignedForUser(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	openPRs := make([]models.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if pr.Status == models.StatusOpen {
+			openPRs = append(openPRs, pr)
+		}
+	}
+
+	return openPRs, nil
+}
+
+// AddAbsence заводит период отсутствия: пока он идёт, пользователь не назначается на ревью.
+func (s *UserService) AddAbsence(ctx context.Context, absence *models.Absence) (*models.Absence, error) {
+	ctx, span := tracing.Start(ctx, "UserService.AddAbsence")
+	defer span.End()
+
+	absence.StartsAt = absence.StartsAt.UTC()
+	absence.EndsAt = absence.EndsAt.UTC()
+	if !absence.StartsAt.Before(absence.EndsAt) {
+		return nil, apperrors.ErrInvalidPeriod
+	}
+
+	exists, err := s.userRepo.Exists(ctx, s.db.Conn(), absence.UserID)
 	if err != nil {
 		return nil, err
 	}
+	if !exists {
+		return nil, apperrors.ErrNotFound
+	}
+
+	if absence.ID, err = s.absenceRepo.Create(ctx, s.db.Conn(), absence); err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+func (s *UserService) GetAbsences(ctx context.Context, userID string) ([]models.Absence, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAbsences")
+	defer span.End()
 
-	teamName, err := s.teamRepo.GetByID(ctx, s.db.Pool(), user.TeamID)
+	exists, err := s.userRepo.Exists(ctx, s.db.Conn(), userID)
 	if err != nil {
 		return nil, err
 	}
+	if !exists {
+		return nil, apperrors.ErrNotFound
+	}
+
+	return s.absenceRepo.GetByUserID(ctx, s.db.Conn(), userID)
+}
+
+func (s *UserService) DeleteAbsence(ctx context.Context, absenceID int) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteAbsence")
+	defer span.End()
 
+	return s.absenceRepo.Delete(ctx, s.db.Conn(), absenceID)
+}
+
+func toAPIUser(user *models.User, teamName string) *api.User {
 	return &api.User{
-		UserId:   user.ID,
-		Username: user.Name,
-		IsActive: user.IsActive,
-		TeamName: teamName,
-	}, nil
+		UserId:         user.ID,
+		Username:       user.Name,
+		IsActive:       user.IsActive,
+		TeamName:       teamName,
+		MaxOpenReviews: user.MaxOpenReviews,
+	}
 }
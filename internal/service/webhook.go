@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/auth"
+	"github.com/AntonTsoy/review-pull-request-service/internal/database"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+)
+
+type WebhookService struct {
+	db           database.Storage
+	loginRepo    repository.LoginRepository
+	deliveryRepo repository.DeliveryRepository
+	userRepo     repository.UserRepository
+	prService    *PullRequestService
+}
+
+func newWebhookService(
+	db database.Storage,
+	loginRepo repository.LoginRepository,
+	deliveryRepo repository.DeliveryRepository,
+	userRepo repository.UserRepository,
+	prService *PullRequestService,
+) *WebhookService {
+	return &WebhookService{
+		db:           db,
+		loginRepo:    loginRepo,
+		deliveryRepo: deliveryRepo,
+		userRepo:     userRepo,
+		prService:    prService,
+	}
+}
+
+func (s *WebhookService) LinkLogin(ctx context.Context, login *models.UserLogin) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.LinkLogin")
+	defer span.End()
+
+	if !isKnownProvider(login.Provider) {
+		return apperrors.ErrUnknownProvider
+	}
+
+	exists, err := s.userRepo.Exists(ctx, s.db.Conn(), login.UserID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperrors.ErrNotFound
+	}
+
+	return s.loginRepo.Link(ctx, s.db.Conn(), login)
+}
+
+func (s *WebhookService) UnlinkLogin(ctx context.Context, provider models.Provider, login string) (*models.UserLogin, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.UnlinkLogin")
+	defer span.End()
+
+	if !isKnownProvider(provider) {
+		return nil, apperrors.ErrUnknownProvider
+	}
+
+	return s.loginRepo.Unlink(ctx, s.db.Conn(), provider, login)
+}
+
+// errDuplicateDelivery откатывает транзакцию, в которой доставку уже успел сохранить параллельный повтор.
+var errDuplicateDelivery = errors.New("webhook delivery is already processed")
+
+// Process применяет событие внешней системы к PR и запоминает его delivery id.
+// Повторная доставка с тем же id ничего не меняет и возвращает WebhookDuplicate.
+//
+// Доставка сохраняется первым шагом в транзакции самого изменения PR: упавшая обработка откатывает
+// и её, так что доставку можно отправить повторно, а из одновременных повторов изменение применит
+// только тот, чья вставка прошла первой, остальные получат WebhookDuplicate.
+func (s *WebhookService) Process(ctx context.Context, event *models.WebhookEvent) (models.WebhookResult, *models.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Process")
+	defer span.End()
+
+	seen, err := s.deliveryRepo.Exists(ctx, s.db.Conn(), event.Provider, event.DeliveryID)
+	if err != nil {
+		return "", nil, err
+	}
+	if seen {
+		return models.WebhookDuplicate, nil, nil
+	}
+
+	// в аудите изменения из вебхука записываются на внешнюю систему
+	ctx = auth.WithIdentity(ctx, &auth.Identity{Subject: "webhook:" + strings.ToLower(event.Provider)})
+
+	pr, err := s.apply(ctx, event, func(ctx context.Context, tx database.Tx) error {
+		saved, err := s.deliveryRepo.Save(ctx, tx, event.Provider, event.DeliveryID, event.Action)
+		if err != nil {
+			return err
+		}
+		if !saved {
+			return errDuplicateDelivery
+		}
+
+		return nil
+	})
+	if errors.Is(err, errDuplicateDelivery) {
+		return models.WebhookDuplicate, nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	return models.WebhookProcessed, pr, nil
+}
+
+func (s *WebhookService) apply(ctx context.Context, event *models.WebhookEvent, claim txHook) (*models.PullRequest, error) {
+	switch event.Action {
+	case models.WebhookOpened:
+		authorID, err := s.resolveLogin(ctx, event.Provider, event.AuthorLogin)
+		if err != nil {
+			return nil, err
+		}
+
+		pr, err := s.prService.create(ctx, &api.PostPullRequestCreateJSONRequestBody{
+			PullRequestId:   event.PullRequestID,
+			PullRequestName: event.Title,
+			AuthorId:        authorID,
+		}, &event.Provider, claim)
+		if errors.Is(err, apperrors.ErrPullRequestExists) {
+			// PR уже заведён (например, через API): доставка запоминается, PR возвращается без изменений
+			return s.claimExisting(ctx, event.PullRequestID, claim)
+		}
+		return pr, err
+	case models.WebhookMerged:
+		// merge во внешней системе уже произошёл, поэтому одобрения здесь не проверяются
+		return s.prService.merge(ctx, event.PullRequestID, false, claim)
+	case models.WebhookClosed:
+		return s.prService.setStatus(ctx, event.PullRequestID, s.prService.prRepo.UpdateCloseStatus, claim)
+	case models.WebhookReopened:
+		return s.prService.setStatus(ctx, event.PullRequestID, s.prService.prRepo.Reopen, claim)
+	case models.WebhookReviewed:
+		reviewerID, err := s.resolveLogin(ctx, event.Provider, event.ReviewerLogin)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.prService.submitReview(ctx, event.PullRequestID, reviewerID, event.ReviewState, event.Comment, claim)
+	default:
+		return nil, fmt.Errorf("unsupported webhook action %q", event.Action)
+	}
+}
+
+func (s *WebhookService) claimExisting(ctx context.Context, prID string, claim txHook) (*models.PullRequest, error) {
+	var pr *models.PullRequest
+	err := database.RunInTx(ctx, s.db, func(tx database.Tx) error {
+		err := claim(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		pr, err = s.prService.getWithReviewers(ctx, tx, prID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *WebhookService) resolveLogin(ctx context.Context, provider models.Provider, login string) (string, error) {
+	userID, err := s.loginRepo.Resolve(ctx, s.db.Conn(), provider, login)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return "", fmt.Errorf("%w: %s login %q is not linked to a user", err, strings.ToLower(provider), login)
+	}
+
+	return userID, err
+}
+
+func isKnownProvider(provider models.Provider) bool {
+	switch provider {
+	case models.ProviderGitHub, models.ProviderGitLab:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	DBSystemPostgres = semconv.DBSystemPostgreSQL
+	DBSystemSQLite   = semconv.DBSystemSqlite
+)
+
+// StartQuery открывает span запроса к БД с текстом SQL. Span называется по методу репозитория,
+// из которого пришёл запрос, поэтому StartQuery нужно вызывать из обёртки над соединением,
+// которую репозиторий вызывает напрямую. Запросы вне трассируемого контекста (например,
+// опрос outbox диспетчером) не записываются, чтобы не плодить трассировки из одного запроса.
+func StartQuery(ctx context.Context, system attribute.KeyValue, statement string) (context.Context, trace.Span) {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	return Tracer().Start(ctx, callerName(3),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(system, semconv.DBQueryText(statement)),
+	)
+}
+
+// callerName превращает github.com/.../postgres.(*pullRequestRepository).Create в postgres.pullRequestRepository.Create.
+func callerName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "db.query"
+	}
+
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
@@ -13,7 +13,19 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
-	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// Журнал аудита изменений, новые записи первыми
+	// (GET /audit)
+	GetAudit(c *fiber.Ctx, params GetAuditParams) error
+	// Проба живости
+	// (GET /health/live)
+	GetHealthLive(c *fiber.Ctx) error
+	// Проба готовности
+	// (GET /health/ready)
+	GetHealthReady(c *fiber.Ctx) error
+	// Закрыть PR без merge (идемпотентная операция), ревьюверы замораживаются
+	// (POST /pullRequest/close)
+	PostPullRequestClose(c *fiber.Ctx) error
+	// Создать PR и автоматически назначить до reviewers_required ревьюверов из команды автора
 	// (POST /pullRequest/create)
 	PostPullRequestCreate(c *fiber.Ctx) error
 	// Пометить PR как MERGED (идемпотентная операция)
@@ -22,18 +34,84 @@ type ServerInterface interface {
 	// Переназначить конкретного ревьювера на другого из его команды
 	// (POST /pullRequest/reassign)
 	PostPullRequestReassign(c *fiber.Ctx) error
+	// Переоткрыть закрытый PR
+	// (POST /pullRequest/reopen)
+	PostPullRequestReopen(c *fiber.Ctx) error
+	// Отправить решение ревьювера по PR (APPROVED или CHANGES_REQUESTED)
+	// (POST /pullRequest/review)
+	PostPullRequestReview(c *fiber.Ctx) error
+	// Статистика назначений на ревью по пользователям
+	// (GET /stats/assignments)
+	GetStatsAssignments(c *fiber.Ctx, params GetStatsAssignmentsParams) error
+	// Статистика PR по командам
+	// (GET /stats/pullRequests)
+	GetStatsPullRequests(c *fiber.Ctx, params GetStatsPullRequestsParams) error
 	// Создать команду с участниками (создаёт/обновляет пользователей)
 	// (POST /team/add)
 	PostTeamAdd(c *fiber.Ctx) error
+	// Массово деактивировать участников команды и переназначить их открытые PR
+	// (POST /team/deactivateMembers)
+	PostTeamDeactivateMembers(c *fiber.Ctx) error
 	// Получить команду с участниками
 	// (GET /team/get)
 	GetTeamGet(c *fiber.Ctx, params GetTeamGetParams) error
+	// Обновить настройки команды (стратегия выбора ревьюверов)
+	// (POST /team/settings)
+	PostTeamSettings(c *fiber.Ctx) error
+	// Выпустить токен доступа
+	// (POST /tokens/issue)
+	PostTokensIssue(c *fiber.Ctx) error
+	// Получить выпущенные токены (без открытых значений)
+	// (GET /tokens/list)
+	GetTokensList(c *fiber.Ctx) error
+	// Отозвать токен
+	// (POST /tokens/revoke)
+	PostTokensRevoke(c *fiber.Ctx) error
+	// Добавить период отсутствия (отпуск, больничный), пока он идёт, пользователь не назначается на ревью
+	// (POST /users/addAbsence)
+	PostUsersAddAbsence(c *fiber.Ctx) error
+	// Удалить период отсутствия
+	// (POST /users/deleteAbsence)
+	PostUsersDeleteAbsence(c *fiber.Ctx) error
+	// Получить периоды отсутствия пользователя
+	// (GET /users/getAbsences)
+	GetUsersGetAbsences(c *fiber.Ctx, params GetUsersGetAbsencesParams) error
 	// Получить PR'ы, где пользователь назначен ревьювером
 	// (GET /users/getReview)
 	GetUsersGetReview(c *fiber.Ctx, params GetUsersGetReviewParams) error
+	// Привязать логин внешней системы к пользователю (нужно для вебхуков)
+	// (POST /users/linkLogin)
+	PostUsersLinkLogin(c *fiber.Ctx) error
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
 	PostUsersSetIsActive(c *fiber.Ctx) error
+	// Отвязать логин внешней системы
+	// (POST /users/unlinkLogin)
+	PostUsersUnlinkLogin(c *fiber.Ctx) error
+	// Обновить профиль пользователя (имя, лимит открытых ревью)
+	// (POST /users/update)
+	PostUsersUpdate(c *fiber.Ctx) error
+	// Принять вебхук GitHub (события pull_request и pull_request_review)
+	// (POST /webhooks/github)
+	PostWebhooksGithub(c *fiber.Ctx) error
+	// Принять вебхук GitLab (событие Merge Request Hook)
+	// (POST /webhooks/gitlab)
+	PostWebhooksGitlab(c *fiber.Ctx) error
+	// Получить подписки на исходящие вебхуки
+	// (GET /webhooks/subscriptions)
+	GetWebhooksSubscriptions(c *fiber.Ctx) error
+	// Подписаться на события сервиса
+	// (POST /webhooks/subscriptions)
+	PostWebhooksSubscriptions(c *fiber.Ctx) error
+	// Удалить подписку вместе с журналом доставок
+	// (POST /webhooks/subscriptions/delete)
+	PostWebhooksSubscriptionsDelete(c *fiber.Ctx) error
+	// Журнал доставок подписки, новые первыми
+	// (GET /webhooks/subscriptions/deliveries)
+	GetWebhooksSubscriptionsDeliveries(c *fiber.Ctx, params GetWebhooksSubscriptionsDeliveriesParams) error
+	// Изменить подписку (url, события, включена ли)
+	// (POST /webhooks/subscriptions/update)
+	PostWebhooksSubscriptionsUpdate(c *fiber.Ctx) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -43,35 +121,232 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
+// GetAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetAudit(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "entity_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entity_type", query, &params.EntityType)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter entity_type: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "entity_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entity_id", query, &params.EntityId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter entity_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", query, &params.Actor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter actor: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetAudit(c, params)
+}
+
+// GetHealthLive operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthLive(c *fiber.Ctx) error {
+
+	return siw.Handler.GetHealthLive(c)
+}
+
+// GetHealthReady operation middleware
+func (siw *ServerInterfaceWrapper) GetHealthReady(c *fiber.Ctx) error {
+
+	return siw.Handler.GetHealthReady(c)
+}
+
+// PostPullRequestClose operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestClose(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostPullRequestClose(c)
+}
+
 // PostPullRequestCreate operation middleware
 func (siw *ServerInterfaceWrapper) PostPullRequestCreate(c *fiber.Ctx) error {
 
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
 	return siw.Handler.PostPullRequestCreate(c)
 }
 
 // PostPullRequestMerge operation middleware
 func (siw *ServerInterfaceWrapper) PostPullRequestMerge(c *fiber.Ctx) error {
 
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
 	return siw.Handler.PostPullRequestMerge(c)
 }
 
 // PostPullRequestReassign operation middleware
 func (siw *ServerInterfaceWrapper) PostPullRequestReassign(c *fiber.Ctx) error {
 
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
 	return siw.Handler.PostPullRequestReassign(c)
 }
 
+// PostPullRequestReopen operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReopen(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostPullRequestReopen(c)
+}
+
+// PostPullRequestReview operation middleware
+func (siw *ServerInterfaceWrapper) PostPullRequestReview(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostPullRequestReview(c)
+}
+
+// GetStatsAssignments operation middleware
+func (siw *ServerInterfaceWrapper) GetStatsAssignments(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsAssignmentsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", query, &params.TeamName)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter team_name: %w", err).Error())
+	}
+
+	return siw.Handler.GetStatsAssignments(c, params)
+}
+
+// GetStatsPullRequests operation middleware
+func (siw *ServerInterfaceWrapper) GetStatsPullRequests(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsPullRequestsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", query, &params.From)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter from: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", query, &params.To)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter to: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", query, &params.TeamName)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter team_name: %w", err).Error())
+	}
+
+	return siw.Handler.GetStatsPullRequests(c, params)
+}
+
 // PostTeamAdd operation middleware
 func (siw *ServerInterfaceWrapper) PostTeamAdd(c *fiber.Ctx) error {
 
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
 	return siw.Handler.PostTeamAdd(c)
 }
 
+// PostTeamDeactivateMembers operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamDeactivateMembers(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostTeamDeactivateMembers(c)
+}
+
 // GetTeamGet operation middleware
 func (siw *ServerInterfaceWrapper) GetTeamGet(c *fiber.Ctx) error {
 
 	var err error
 
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	c.Context().SetUserValue(UserTokenScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
 	var params GetTeamGetParams
 
@@ -99,13 +374,63 @@ func (siw *ServerInterfaceWrapper) GetTeamGet(c *fiber.Ctx) error {
 	return siw.Handler.GetTeamGet(c, params)
 }
 
-// GetUsersGetReview operation middleware
-func (siw *ServerInterfaceWrapper) GetUsersGetReview(c *fiber.Ctx) error {
+// PostTeamSettings operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamSettings(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostTeamSettings(c)
+}
+
+// PostTokensIssue operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensIssue(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostTokensIssue(c)
+}
+
+// GetTokensList operation middleware
+func (siw *ServerInterfaceWrapper) GetTokensList(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.GetTokensList(c)
+}
+
+// PostTokensRevoke operation middleware
+func (siw *ServerInterfaceWrapper) PostTokensRevoke(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostTokensRevoke(c)
+}
+
+// PostUsersAddAbsence operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersAddAbsence(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostUsersAddAbsence(c)
+}
+
+// PostUsersDeleteAbsence operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersDeleteAbsence(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostUsersDeleteAbsence(c)
+}
+
+// GetUsersGetAbsences operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersGetAbsences(c *fiber.Ctx) error {
 
 	var err error
 
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetUsersGetReviewParams
+	var params GetUsersGetAbsencesParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -128,15 +453,154 @@ func (siw *ServerInterfaceWrapper) GetUsersGetReview(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
 	}
 
+	return siw.Handler.GetUsersGetAbsences(c, params)
+}
+
+// GetUsersGetReview operation middleware
+func (siw *ServerInterfaceWrapper) GetUsersGetReview(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	c.Context().SetUserValue(UserTokenScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersGetReviewParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", query, &params.UserId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter user_id: %w", err).Error())
+	}
+
 	return siw.Handler.GetUsersGetReview(c, params)
 }
 
+// PostUsersLinkLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersLinkLogin(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostUsersLinkLogin(c)
+}
+
 // PostUsersSetIsActive operation middleware
 func (siw *ServerInterfaceWrapper) PostUsersSetIsActive(c *fiber.Ctx) error {
 
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
 	return siw.Handler.PostUsersSetIsActive(c)
 }
 
+// PostUsersUnlinkLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUnlinkLogin(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostUsersUnlinkLogin(c)
+}
+
+// PostUsersUpdate operation middleware
+func (siw *ServerInterfaceWrapper) PostUsersUpdate(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostUsersUpdate(c)
+}
+
+// PostWebhooksGithub operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksGithub(c *fiber.Ctx) error {
+
+	return siw.Handler.PostWebhooksGithub(c)
+}
+
+// PostWebhooksGitlab operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksGitlab(c *fiber.Ctx) error {
+
+	return siw.Handler.PostWebhooksGitlab(c)
+}
+
+// GetWebhooksSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksSubscriptions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.GetWebhooksSubscriptions(c)
+}
+
+// PostWebhooksSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksSubscriptions(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostWebhooksSubscriptions(c)
+}
+
+// PostWebhooksSubscriptionsDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksSubscriptionsDelete(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostWebhooksSubscriptionsDelete(c)
+}
+
+// GetWebhooksSubscriptionsDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooksSubscriptionsDeliveries(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhooksSubscriptionsDeliveriesParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "subscription_id" -------------
+
+	if paramValue := c.Query("subscription_id"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument subscription_id is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "subscription_id", query, &params.SubscriptionId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter subscription_id: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	return siw.Handler.GetWebhooksSubscriptionsDeliveries(c, params)
+}
+
+// PostWebhooksSubscriptionsUpdate operation middleware
+func (siw *ServerInterfaceWrapper) PostWebhooksSubscriptionsUpdate(c *fiber.Ctx) error {
+
+	c.Context().SetUserValue(AdminTokenScopes, []string{})
+
+	return siw.Handler.PostWebhooksSubscriptionsUpdate(c)
+}
+
 // FiberServerOptions provides options for the Fiber server.
 type FiberServerOptions struct {
 	BaseURL     string
@@ -158,18 +622,70 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 		router.Use(m)
 	}
 
+	router.Get(options.BaseURL+"/audit", wrapper.GetAudit)
+
+	router.Get(options.BaseURL+"/health/live", wrapper.GetHealthLive)
+
+	router.Get(options.BaseURL+"/health/ready", wrapper.GetHealthReady)
+
+	router.Post(options.BaseURL+"/pullRequest/close", wrapper.PostPullRequestClose)
+
 	router.Post(options.BaseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
 
 	router.Post(options.BaseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
 
 	router.Post(options.BaseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
 
+	router.Post(options.BaseURL+"/pullRequest/reopen", wrapper.PostPullRequestReopen)
+
+	router.Post(options.BaseURL+"/pullRequest/review", wrapper.PostPullRequestReview)
+
+	router.Get(options.BaseURL+"/stats/assignments", wrapper.GetStatsAssignments)
+
+	router.Get(options.BaseURL+"/stats/pullRequests", wrapper.GetStatsPullRequests)
+
 	router.Post(options.BaseURL+"/team/add", wrapper.PostTeamAdd)
 
+	router.Post(options.BaseURL+"/team/deactivateMembers", wrapper.PostTeamDeactivateMembers)
+
 	router.Get(options.BaseURL+"/team/get", wrapper.GetTeamGet)
 
+	router.Post(options.BaseURL+"/team/settings", wrapper.PostTeamSettings)
+
+	router.Post(options.BaseURL+"/tokens/issue", wrapper.PostTokensIssue)
+
+	router.Get(options.BaseURL+"/tokens/list", wrapper.GetTokensList)
+
+	router.Post(options.BaseURL+"/tokens/revoke", wrapper.PostTokensRevoke)
+
+	router.Post(options.BaseURL+"/users/addAbsence", wrapper.PostUsersAddAbsence)
+
+	router.Post(options.BaseURL+"/users/deleteAbsence", wrapper.PostUsersDeleteAbsence)
+
+	router.Get(options.BaseURL+"/users/getAbsences", wrapper.GetUsersGetAbsences)
+
 	router.Get(options.BaseURL+"/users/getReview", wrapper.GetUsersGetReview)
 
+	router.Post(options.BaseURL+"/users/linkLogin", wrapper.PostUsersLinkLogin)
+
 	router.Post(options.BaseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
 
+	router.Post(options.BaseURL+"/users/unlinkLogin", wrapper.PostUsersUnlinkLogin)
+
+	router.Post(options.BaseURL+"/users/update", wrapper.PostUsersUpdate)
+
+	router.Post(options.BaseURL+"/webhooks/github", wrapper.PostWebhooksGithub)
+
+	router.Post(options.BaseURL+"/webhooks/gitlab", wrapper.PostWebhooksGitlab)
+
+	router.Get(options.BaseURL+"/webhooks/subscriptions", wrapper.GetWebhooksSubscriptions)
+
+	router.Post(options.BaseURL+"/webhooks/subscriptions", wrapper.PostWebhooksSubscriptions)
+
+	router.Post(options.BaseURL+"/webhooks/subscriptions/delete", wrapper.PostWebhooksSubscriptionsDelete)
+
+	router.Get(options.BaseURL+"/webhooks/subscriptions/deliveries", wrapper.GetWebhooksSubscriptionsDeliveries)
+
+	router.Post(options.BaseURL+"/webhooks/subscriptions/update", wrapper.PostWebhooksSubscriptionsUpdate)
+
 }
@@ -7,28 +7,136 @@ import (
 	"time"
 )
 
+const (
+	AdminTokenScopes = "AdminToken.Scopes"
+	UserTokenScopes  = "UserToken.Scopes"
+)
+
+// Defines values for AuditEntityType.
+const (
+	AuditEntityTypePULLREQUEST AuditEntityType = "PULL_REQUEST"
+	AuditEntityTypeTEAM        AuditEntityType = "TEAM"
+	AuditEntityTypeUSER        AuditEntityType = "USER"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	FORBIDDEN    ErrorResponseErrorCode = "FORBIDDEN"
+	NOCANDIDATE  ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTAPPROVED  ErrorResponseErrorCode = "NOT_APPROVED"
+	NOTASSIGNED  ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND     ErrorResponseErrorCode = "NOT_FOUND"
+	PRCLOSED     ErrorResponseErrorCode = "PR_CLOSED"
+	PREXISTS     ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED     ErrorResponseErrorCode = "PR_MERGED"
+	TEAMEXISTS   ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNAUTHORIZED ErrorResponseErrorCode = "UNAUTHORIZED"
+)
+
+// Defines values for HealthStatus.
+const (
+	DEGRADED HealthStatus = "DEGRADED"
+	DOWN     HealthStatus = "DOWN"
+	UP       HealthStatus = "UP"
+)
+
+// Defines values for LoginProvider.
+const (
+	GITHUB LoginProvider = "GITHUB"
+	GITLAB LoginProvider = "GITLAB"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for ReviewState.
+const (
+	APPROVED         ReviewState = "APPROVED"
+	CHANGESREQUESTED ReviewState = "CHANGES_REQUESTED"
+	PENDING          ReviewState = "PENDING"
+)
+
+// Defines values for ReviewerStrategy.
+const (
+	LEASTLOADED ReviewerStrategy = "LEAST_LOADED"
+	RANDOM      ReviewerStrategy = "RANDOM"
+	ROUNDROBIN  ReviewerStrategy = "ROUND_ROBIN"
+	WEIGHTED    ReviewerStrategy = "WEIGHTED"
+)
+
+// Defines values for TokenRole.
+const (
+	TokenRoleADMIN TokenRole = "ADMIN"
+	TokenRoleUSER  TokenRole = "USER"
+)
+
+// Defines values for WebhookEventType.
+const (
+	PrCreated          WebhookEventType = "pr.created"
+	PrMerged           WebhookEventType = "pr.merged"
+	ReviewerAssigned   WebhookEventType = "reviewer.assigned"
+	ReviewerReassigned WebhookEventType = "reviewer.reassigned"
+)
+
+// Defines values for WebhookResultResult.
+const (
+	DUPLICATE WebhookResultResult = "DUPLICATE"
+	IGNORED   WebhookResultResult = "IGNORED"
+	PROCESSED WebhookResultResult = "PROCESSED"
+)
+
+// ApiToken defines model for ApiToken.
+type ApiToken struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Name Кому или для чего выпущен токен
+	Name      string     `json:"name"`
+	RevokedAt *time.Time `json:"revoked_at"`
+
+	// Role ADMIN — полный доступ, USER — только чтение /users/getReview и /team/get
+	Role    TokenRole `json:"role"`
+	TokenId int       `json:"token_id"`
+}
+
+// AuditEntityType defines model for AuditEntityType.
+type AuditEntityType string
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Action team.created, user.is_active_changed, pr.created, pr.merged или reviewer.reassigned
+	Action string `json:"action"`
+
+	// Actor Субъект токена вызывающего (user_id из JWT или имя токена), webhook:github или webhook:gitlab
+	// для изменений из вебхуков, anonymous при выключенной аутентификации
+	Actor string `json:"actor"`
+
+	// After Снимок сущности после изменения
+	After   *map[string]interface{} `json:"after"`
+	AuditId int64                   `json:"audit_id"`
+
+	// Before Снимок сущности до изменения; null, если сущности не было
+	Before    *map[string]interface{} `json:"before"`
+	CreatedAt time.Time               `json:"created_at"`
+
+	// EntityId Имя команды, user_id или pull_request_id
+	EntityId   string          `json:"entity_id"`
+	EntityType AuditEntityType `json:"entity_type"`
+
+	// RequestId Id запроса из заголовка X-Request-ID
+	RequestId *string `json:"request_id"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -40,16 +148,102 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// GitHubPullRequest defines model for GitHubPullRequest.
+type GitHubPullRequest struct {
+	// Id Глобальный id PR в GitHub, из него строится pull_request_id (github-<id>)
+	Id     int64      `json:"id"`
+	Merged *bool      `json:"merged,omitempty"`
+	Number int        `json:"number"`
+	Title  string     `json:"title"`
+	User   GitHubUser `json:"user"`
+}
+
+// GitHubReview defines model for GitHubReview.
+type GitHubReview struct {
+	Body *string `json:"body"`
+
+	// State approved, changes_requested или commented (commented игнорируется)
+	State string     `json:"state"`
+	User  GitHubUser `json:"user"`
+}
+
+// GitHubUser defines model for GitHubUser.
+type GitHubUser struct {
+	Login string `json:"login"`
+}
+
+// GitHubWebhookPayload Нужная сервису часть payload событий pull_request и pull_request_review, остальные поля игнорируются
+type GitHubWebhookPayload struct {
+	Action      *string            `json:"action,omitempty"`
+	PullRequest *GitHubPullRequest `json:"pull_request,omitempty"`
+	Review      *GitHubReview      `json:"review,omitempty"`
+}
+
+// GitLabMergeRequest defines model for GitLabMergeRequest.
+type GitLabMergeRequest struct {
+	// Action open, reopen, close, merge, approved или approval (остальные игнорируются)
+	Action *string `json:"action,omitempty"`
+
+	// Id Глобальный id MR в GitLab, из него строится pull_request_id (gitlab-<id>)
+	Id    int64  `json:"id"`
+	Iid   int    `json:"iid"`
+	Title string `json:"title"`
+}
+
+// GitLabUser defines model for GitLabUser.
+type GitLabUser struct {
+	Username string `json:"username"`
+}
+
+// GitLabWebhookPayload Нужная сервису часть payload события Merge Request Hook, остальные поля игнорируются
+type GitLabWebhookPayload struct {
+	ObjectAttributes *GitLabMergeRequest `json:"object_attributes,omitempty"`
+	ObjectKind       *string             `json:"object_kind,omitempty"`
+	User             *GitLabUser         `json:"user,omitempty"`
+}
+
+// HealthComponent defines model for HealthComponent.
+type HealthComponent struct {
+	// Details Показатели проверки, например версия схемы или число занятых соединений пула
+	Details *map[string]interface{} `json:"details,omitempty"`
+
+	// Error Причина DOWN или DEGRADED
+	Error  *string      `json:"error,omitempty"`
+	Status HealthStatus `json:"status"`
+}
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus string
+
+// Liveness defines model for Liveness.
+type Liveness struct {
+	Status HealthStatus `json:"status"`
+}
+
+// LoginProvider Внешняя система, из которой приходят вебхуки
+type LoginProvider string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..reviewers_required)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	ClosedAt          *time.Time `json:"closedAt"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	MergedAt          *time.Time `json:"mergedAt"`
+
+	// MissingReviewers Сколько ревьюверов не удалось назначить из-за нехватки кандидатов
+	MissingReviewers *int   `json:"missing_reviewers,omitempty"`
+	PullRequestId    string `json:"pull_request_id"`
+	PullRequestName  string `json:"pull_request_name"`
+
+	// ReviewersRequired Сколько ревьюверов требуется на PR
+	ReviewersRequired *int `json:"reviewers_required,omitempty"`
+
+	// Reviews Состояние ревью по каждому назначенному ревьюверу
+	Reviews *[]Review         `json:"reviews,omitempty"`
+	Source  *LoginProvider    `json:"source,omitempty"`
+	Status  PullRequestStatus `json:"status"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
@@ -66,38 +260,236 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// Readiness defines model for Readiness.
+type Readiness struct {
+	// Components Проверки database, migrations и pool; в STORAGE=memory есть только database
+	Components map[string]HealthComponent `json:"components"`
+
+	// ShuttingDown Сервис завершает работу и ждёт, пока балансировщик перестанет присылать запросы
+	ShuttingDown bool         `json:"shutting_down"`
+	Status       HealthStatus `json:"status"`
+}
+
+// Reassignment defines model for Reassignment.
+type Reassignment struct {
+	// MissingReviewers Сколько ревьюверов не хватает до reviewers_required после переназначения
+	MissingReviewers int `json:"missing_reviewers"`
+
+	// NewReviewers Назначенные вместо них ревьюверы (может быть пустым)
+	NewReviewers []string `json:"new_reviewers"`
+
+	// OldReviewers Снятые с PR ревьюверы
+	OldReviewers  []string `json:"old_reviewers"`
+	PullRequestId string   `json:"pull_request_id"`
+}
+
+// Review defines model for Review.
+type Review struct {
+	// Comment Комментарий к последнему решению ревьювера
+	Comment    *string     `json:"comment,omitempty"`
+	ReviewedAt *time.Time  `json:"reviewed_at"`
+	ReviewerId string      `json:"reviewer_id"`
+	State      ReviewState `json:"state"`
+}
+
+// ReviewState Состояние ревью конкретного ревьювера
+type ReviewState string
+
+// ReviewerStrategy Стратегия выбора ревьюверов в команде (по умолчанию LEAST_LOADED)
+type ReviewerStrategy string
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	// ChatWebhookUrl Incoming webhook Slack/Mattermost для уведомлений команды; пустая строка отключает уведомления
+	ChatWebhookUrl *string      `json:"chat_webhook_url,omitempty"`
+	Members        []TeamMember `json:"members"`
+
+	// RequireApprovals Разрешать merge только после APPROVED от всех назначенных ревьюверов
+	RequireApprovals *bool `json:"require_approvals,omitempty"`
+
+	// ReviewerStrategy Стратегия выбора ревьюверов в команде (по умолчанию LEAST_LOADED)
+	ReviewerStrategy *ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+
+	// ReviewersRequired Сколько ревьюверов назначать на PR (по умолчанию 2)
+	ReviewersRequired *int   `json:"reviewers_required,omitempty"`
+	TeamName          string `json:"team_name"`
 }
 
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// MaxOpenReviews Максимум одновременных открытых ревью; 0 или отсутствие — без ограничения
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+}
+
+// TeamPullRequestStats defines model for TeamPullRequestStats.
+type TeamPullRequestStats struct {
+	// AvgTimeToMergeSeconds Среднее время от создания до merge, null если merge не было
+	AvgTimeToMergeSeconds *float64 `json:"avg_time_to_merge_seconds"`
+	Merged                int      `json:"merged"`
+	Open                  int      `json:"open"`
+	TeamName              string   `json:"team_name"`
+
+	// WithoutReviewers PR без единого назначенного ревьювера
+	WithoutReviewers int `json:"without_reviewers"`
+}
+
+// TeamSettings defines model for TeamSettings.
+type TeamSettings struct {
+	// ChatWebhookUrl Incoming webhook Slack/Mattermost для уведомлений команды; пустая строка отключает уведомления
+	ChatWebhookUrl *string `json:"chat_webhook_url,omitempty"`
+
+	// RequireApprovals Разрешать merge только после APPROVED от всех назначенных ревьюверов
+	RequireApprovals *bool `json:"require_approvals,omitempty"`
+
+	// ReviewerStrategy Стратегия выбора ревьюверов в команде (по умолчанию LEAST_LOADED)
+	ReviewerStrategy *ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+
+	// ReviewersRequired Сколько ревьюверов назначать на PR (по умолчанию 2)
+	ReviewersRequired *int   `json:"reviewers_required,omitempty"`
+	TeamName          string `json:"team_name"`
 }
 
+// TokenRole ADMIN — полный доступ, USER — только чтение /users/getReview и /team/get
+type TokenRole string
+
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
+	IsActive bool `json:"is_active"`
+
+	// MaxOpenReviews Максимум одновременных открытых ревью; 0 или отсутствие — без ограничения
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
+	TeamName       string `json:"team_name"`
+	UserId         string `json:"user_id"`
+	Username       string `json:"username"`
+}
+
+// UserAbsence defines model for UserAbsence.
+type UserAbsence struct {
+	AbsenceId int       `json:"absence_id"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    *string   `json:"reason,omitempty"`
+	StartsAt  time.Time `json:"starts_at"`
+	UserId    string    `json:"user_id"`
+}
+
+// UserAssignmentStats defines model for UserAssignmentStats.
+type UserAssignmentStats struct {
+	Merged   int    `json:"merged"`
+	Open     int    `json:"open"`
 	TeamName string `json:"team_name"`
+
+	// Total Всего назначений на ревью
+	Total    int    `json:"total"`
 	UserId   string `json:"user_id"`
 	Username string `json:"username"`
 }
 
+// UserLogin defines model for UserLogin.
+type UserLogin struct {
+	// Login Логин пользователя во внешней системе
+	Login    string        `json:"login"`
+	Provider LoginProvider `json:"provider"`
+	UserId   string        `json:"user_id"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+
+	// DeliveryId Совпадает с заголовком X-Webhook-Delivery, по нему подписчик отбрасывает повторы
+	DeliveryId int64 `json:"delivery_id"`
+	EventId    int64 `json:"event_id"`
+
+	// EventType Тип события для подписчиков исходящих вебхуков
+	EventType WebhookEventType `json:"event_type"`
+	LastError *string          `json:"last_error"`
+
+	// LastStatusCode HTTP-статус последнего ответа подписчика
+	LastStatusCode *int      `json:"last_status_code"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+
+	// Status PENDING — ждёт отправки или повтора, DELIVERED — доставлено, FAILED — попытки исчерпаны
+	Status         string `json:"status"`
+	SubscriptionId int    `json:"subscription_id"`
+}
+
+// WebhookEventType Тип события для подписчиков исходящих вебхуков
+type WebhookEventType string
+
+// WebhookResult defines model for WebhookResult.
+type WebhookResult struct {
+	DeliveryId string       `json:"delivery_id"`
+	Pr         *PullRequest `json:"pr,omitempty"`
+
+	// Result DUPLICATE — доставка с этим id уже обработана, IGNORED — событие сервису не интересно
+	Result WebhookResultResult `json:"result"`
+}
+
+// WebhookResultResult DUPLICATE — доставка с этим id уже обработана, IGNORED — событие сервису не интересно
+type WebhookResultResult string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	Events         []WebhookEventType `json:"events"`
+	IsActive       bool               `json:"is_active"`
+	SubscriptionId int                `json:"subscription_id"`
+
+	// Url Адрес, на который отправляются события (POST, application/json)
+	Url string `json:"url"`
+}
+
+// PeriodFromQuery defines model for PeriodFromQuery.
+type PeriodFromQuery = time.Time
+
+// PeriodToQuery defines model for PeriodToQuery.
+type PeriodToQuery = time.Time
+
+// TeamFilterQuery defines model for TeamFilterQuery.
+type TeamFilterQuery = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// GetAuditParams defines parameters for GetAudit.
+type GetAuditParams struct {
+	EntityType *AuditEntityType `form:"entity_type,omitempty" json:"entity_type,omitempty"`
+
+	// EntityId Имя команды, user_id или pull_request_id
+	EntityId *string `form:"entity_id,omitempty" json:"entity_id,omitempty"`
+	Actor    *string `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// From Начало периода (включительно)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода (не включительно)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Сколько последних записей вернуть (по умолчанию 100)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
 	AuthorId        string `json:"author_id"`
 	PullRequestId   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
+
+	// ReviewersRequired Переопределяет настройку команды для этого PR
+	ReviewersRequired *int `json:"reviewers_required,omitempty"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
@@ -111,24 +503,156 @@ type PostPullRequestReassignJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	Comment       *string `json:"comment,omitempty"`
+	PullRequestId string  `json:"pull_request_id"`
+	ReviewerId    string  `json:"reviewer_id"`
+
+	// State Состояние ревью конкретного ревьювера
+	State ReviewState `json:"state"`
+}
+
+// GetStatsAssignmentsParams defines parameters for GetStatsAssignments.
+type GetStatsAssignmentsParams struct {
+	// From Начало периода (включительно) по времени создания PR
+	From *PeriodFromQuery `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода (не включительно) по времени создания PR
+	To *PeriodToQuery `form:"to,omitempty" json:"to,omitempty"`
+
+	// TeamName Ограничить статистику одной командой
+	TeamName *TeamFilterQuery `form:"team_name,omitempty" json:"team_name,omitempty"`
+}
+
+// GetStatsPullRequestsParams defines parameters for GetStatsPullRequests.
+type GetStatsPullRequestsParams struct {
+	// From Начало периода (включительно) по времени создания PR
+	From *PeriodFromQuery `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода (не включительно) по времени создания PR
+	To *PeriodToQuery `form:"to,omitempty" json:"to,omitempty"`
+
+	// TeamName Ограничить статистику одной командой
+	TeamName *TeamFilterQuery `form:"team_name,omitempty" json:"team_name,omitempty"`
+}
+
+// PostTeamDeactivateMembersJSONBody defines parameters for PostTeamDeactivateMembers.
+type PostTeamDeactivateMembersJSONBody struct {
+	TeamName string `json:"team_name"`
+
+	// UserIds Кого деактивировать; если не указан — всю команду
+	UserIds *[]string `json:"user_ids,omitempty"`
+}
+
 // GetTeamGetParams defines parameters for GetTeamGet.
 type GetTeamGetParams struct {
 	// TeamName Уникальное имя команды
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
-// GetUsersGetReviewParams defines parameters for GetUsersGetReview.
-type GetUsersGetReviewParams struct {
+// PostTokensIssueJSONBody defines parameters for PostTokensIssue.
+type PostTokensIssueJSONBody struct {
+	Name string `json:"name"`
+
+	// Role ADMIN — полный доступ, USER — только чтение /users/getReview и /team/get
+	Role TokenRole `json:"role"`
+}
+
+// PostTokensRevokeJSONBody defines parameters for PostTokensRevoke.
+type PostTokensRevokeJSONBody struct {
+	TokenId int `json:"token_id"`
+}
+
+// PostUsersAddAbsenceJSONBody defines parameters for PostUsersAddAbsence.
+type PostUsersAddAbsenceJSONBody struct {
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   *string   `json:"reason,omitempty"`
+	StartsAt time.Time `json:"starts_at"`
+	UserId   string    `json:"user_id"`
+}
+
+// PostUsersDeleteAbsenceJSONBody defines parameters for PostUsersDeleteAbsence.
+type PostUsersDeleteAbsenceJSONBody struct {
+	AbsenceId int `json:"absence_id"`
+}
+
+// GetUsersGetAbsencesParams defines parameters for GetUsersGetAbsences.
+type GetUsersGetAbsencesParams struct {
 	// UserId Идентификатор пользователя
 	UserId UserIdQuery `form:"user_id" json:"user_id"`
 }
 
+// GetUsersGetReviewParams defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParams struct {
+	// UserId Идентификатор пользователя; по умолчанию — субъект JWT вызывающего
+	UserId *string `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
 // PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
 type PostUsersSetIsActiveJSONBody struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
+	IsActive bool `json:"is_active"`
+
+	// ReassignReviews При деактивации переназначить открытые ревью пользователя. Если false — ревью остаются за ним и возвращаются в pending_reviews
+	ReassignReviews *bool  `json:"reassign_reviews,omitempty"`
+	UserId          string `json:"user_id"`
+}
+
+// PostUsersUnlinkLoginJSONBody defines parameters for PostUsersUnlinkLogin.
+type PostUsersUnlinkLoginJSONBody struct {
+	Login    string        `json:"login"`
+	Provider LoginProvider `json:"provider"`
+}
+
+// PostUsersUpdateJSONBody defines parameters for PostUsersUpdate.
+type PostUsersUpdateJSONBody struct {
+	// MaxOpenReviews 0 снимает ограничение
+	MaxOpenReviews *int    `json:"max_open_reviews,omitempty"`
+	UserId         string  `json:"user_id"`
+	Username       *string `json:"username,omitempty"`
+}
+
+// PostWebhooksSubscriptionsJSONBody defines parameters for PostWebhooksSubscriptions.
+type PostWebhooksSubscriptionsJSONBody struct {
+	Events   []WebhookEventType `json:"events"`
+	IsActive *bool              `json:"is_active,omitempty"`
+
+	// Secret Секрет для подписи; если не задан, генерируется
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// PostWebhooksSubscriptionsDeleteJSONBody defines parameters for PostWebhooksSubscriptionsDelete.
+type PostWebhooksSubscriptionsDeleteJSONBody struct {
+	SubscriptionId int `json:"subscription_id"`
+}
+
+// GetWebhooksSubscriptionsDeliveriesParams defines parameters for GetWebhooksSubscriptionsDeliveries.
+type GetWebhooksSubscriptionsDeliveriesParams struct {
+	SubscriptionId int `form:"subscription_id" json:"subscription_id"`
+
+	// Limit Сколько последних доставок вернуть (по умолчанию 50)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostWebhooksSubscriptionsUpdateJSONBody defines parameters for PostWebhooksSubscriptionsUpdate.
+type PostWebhooksSubscriptionsUpdateJSONBody struct {
+	Events *[]WebhookEventType `json:"events,omitempty"`
+
+	// IsActive Выключенная подписка не получает новых событий, а начатые доставки ждут её включения
+	IsActive       *bool   `json:"is_active,omitempty"`
+	SubscriptionId int     `json:"subscription_id"`
+	Url            *string `json:"url,omitempty"`
 }
 
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
@@ -138,8 +662,56 @@ type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamDeactivateMembersJSONRequestBody defines body for PostTeamDeactivateMembers for application/json ContentType.
+type PostTeamDeactivateMembersJSONRequestBody PostTeamDeactivateMembersJSONBody
+
+// PostTeamSettingsJSONRequestBody defines body for PostTeamSettings for application/json ContentType.
+type PostTeamSettingsJSONRequestBody = TeamSettings
+
+// PostTokensIssueJSONRequestBody defines body for PostTokensIssue for application/json ContentType.
+type PostTokensIssueJSONRequestBody PostTokensIssueJSONBody
+
+// PostTokensRevokeJSONRequestBody defines body for PostTokensRevoke for application/json ContentType.
+type PostTokensRevokeJSONRequestBody PostTokensRevokeJSONBody
+
+// PostUsersAddAbsenceJSONRequestBody defines body for PostUsersAddAbsence for application/json ContentType.
+type PostUsersAddAbsenceJSONRequestBody PostUsersAddAbsenceJSONBody
+
+// PostUsersDeleteAbsenceJSONRequestBody defines body for PostUsersDeleteAbsence for application/json ContentType.
+type PostUsersDeleteAbsenceJSONRequestBody PostUsersDeleteAbsenceJSONBody
+
+// PostUsersLinkLoginJSONRequestBody defines body for PostUsersLinkLogin for application/json ContentType.
+type PostUsersLinkLoginJSONRequestBody = UserLogin
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostUsersUnlinkLoginJSONRequestBody defines body for PostUsersUnlinkLogin for application/json ContentType.
+type PostUsersUnlinkLoginJSONRequestBody PostUsersUnlinkLoginJSONBody
+
+// PostUsersUpdateJSONRequestBody defines body for PostUsersUpdate for application/json ContentType.
+type PostUsersUpdateJSONRequestBody PostUsersUpdateJSONBody
+
+// PostWebhooksGithubJSONRequestBody defines body for PostWebhooksGithub for application/json ContentType.
+type PostWebhooksGithubJSONRequestBody = GitHubWebhookPayload
+
+// PostWebhooksGitlabJSONRequestBody defines body for PostWebhooksGitlab for application/json ContentType.
+type PostWebhooksGitlabJSONRequestBody = GitLabWebhookPayload
+
+// PostWebhooksSubscriptionsJSONRequestBody defines body for PostWebhooksSubscriptions for application/json ContentType.
+type PostWebhooksSubscriptionsJSONRequestBody PostWebhooksSubscriptionsJSONBody
+
+// PostWebhooksSubscriptionsDeleteJSONRequestBody defines body for PostWebhooksSubscriptionsDelete for application/json ContentType.
+type PostWebhooksSubscriptionsDeleteJSONRequestBody PostWebhooksSubscriptionsDeleteJSONBody
+
+// PostWebhooksSubscriptionsUpdateJSONRequestBody defines body for PostWebhooksSubscriptionsUpdate for application/json ContentType.
+type PostWebhooksSubscriptionsUpdateJSONRequestBody PostWebhooksSubscriptionsUpdateJSONBody
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/service"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+func newAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+func (h *AuditHandler) GetAudit(c *fiber.Ctx, params api.GetAuditParams) error {
+	filter := models.AuditFilter{
+		EntityID: params.EntityId,
+		Actor:    params.Actor,
+		From:     params.From,
+		To:       params.To,
+	}
+	if params.EntityType != nil {
+		entityType := models.AuditEntity(*params.EntityType)
+		filter.EntityType = &entityType
+	}
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+
+	entries, err := h.auditService.List(c.UserContext(), filter)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := AuditListResponse{Entries: make([]api.AuditEntry, len(entries))}
+	for i := range entries {
+		if resp.Entries[i], err = convertAuditEntryToAPI(&entries[i]); err != nil {
+			return handleError(c, err)
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
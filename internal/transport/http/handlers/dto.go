@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/AntonTsoy/review-pull-request-service/internal/models"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
 )
@@ -14,11 +17,56 @@ type TeamResponse struct {
 	Team api.Team `json:"team"`
 }
 
+type TeamSettingsResponse struct {
+	Settings *api.TeamSettings `json:"settings"`
+}
+
+type TeamDeactivateMembersResponse struct {
+	TeamName           string             `json:"team_name"`
+	DeactivatedUserIds []string           `json:"deactivated_user_ids"`
+	Reassignments      []api.Reassignment `json:"reassignments"`
+}
+
 type UserGetReviewResponse struct {
 	UserId       string                 `json:"user_id"`
 	PullRequests []api.PullRequestShort `json:"pull_requests"`
 }
 
+type UserSetIsActiveResponse struct {
+	User           *api.User              `json:"user"`
+	Reassignments  []api.Reassignment     `json:"reassignments"`
+	PendingReviews []api.PullRequestShort `json:"pending_reviews"`
+}
+
+type UserUpdateResponse struct {
+	User *api.User `json:"user"`
+}
+
+type StatsAssignmentsResponse struct {
+	Assignments []api.UserAssignmentStats `json:"assignments"`
+}
+
+type StatsPullRequestsResponse struct {
+	Teams []api.TeamPullRequestStats `json:"teams"`
+}
+
+type UserAbsenceResponse struct {
+	Absence api.UserAbsence `json:"absence"`
+}
+
+type UserGetAbsencesResponse struct {
+	UserId   string            `json:"user_id"`
+	Absences []api.UserAbsence `json:"absences"`
+}
+
+type UserDeleteAbsenceResponse struct {
+	AbsenceId int `json:"absence_id"`
+}
+
+type UserLoginResponse struct {
+	Login api.UserLogin `json:"login"`
+}
+
 type PullRequestResponse struct {
 	Pr *api.PullRequest `json:"pr"`
 }
@@ -28,13 +76,235 @@ type PullRequestAssignResponse struct {
 	ReplacedBy string           `json:"replaced_by"`
 }
 
+type SubscriptionResponse struct {
+	Subscription api.WebhookSubscription `json:"subscription"`
+}
+
+type SubscriptionCreateResponse struct {
+	Subscription api.WebhookSubscription `json:"subscription"`
+	Secret       string                  `json:"secret"`
+}
+
+type SubscriptionListResponse struct {
+	Subscriptions []api.WebhookSubscription `json:"subscriptions"`
+}
+
+type SubscriptionDeleteResponse struct {
+	SubscriptionId int `json:"subscription_id"`
+}
+
+type SubscriptionDeliveriesResponse struct {
+	SubscriptionId int                   `json:"subscription_id"`
+	Deliveries     []api.WebhookDelivery `json:"deliveries"`
+}
+
+type TokenResponse struct {
+	Token api.ApiToken `json:"token"`
+}
+
+type TokenIssueResponse struct {
+	Token       api.ApiToken `json:"token"`
+	AccessToken string       `json:"access_token"`
+}
+
+type TokenListResponse struct {
+	Tokens []api.ApiToken `json:"tokens"`
+}
+
+type AuditListResponse struct {
+	Entries []api.AuditEntry `json:"entries"`
+}
+
 func convertPRToAPI(pr *models.PullRequest) *api.PullRequest {
+	missingReviewers := max(0, pr.ReviewersRequired-len(pr.AssignedReviewers))
+
+	var reviews *[]api.Review
+	if pr.Reviews != nil {
+		converted := make([]api.Review, len(pr.Reviews))
+		for i, review := range pr.Reviews {
+			converted[i] = api.Review{
+				ReviewerId: review.ReviewerID,
+				State:      api.ReviewState(review.State),
+				Comment:    review.Comment,
+				ReviewedAt: review.ReviewedAt,
+			}
+		}
+		reviews = &converted
+	}
+
 	return &api.PullRequest{
 		PullRequestId:     pr.ID,
 		PullRequestName:   pr.Title,
 		AuthorId:          pr.AuthorID,
 		Status:            api.PullRequestStatus(pr.Status),
 		AssignedReviewers: pr.AssignedReviewers,
+		ReviewersRequired: &pr.ReviewersRequired,
+		MissingReviewers:  &missingReviewers,
 		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
+		Reviews:           reviews,
+		Source:            (*api.LoginProvider)(pr.Source),
+	}
+}
+
+func convertShortPRsToAPI(prs []models.PullRequest) []api.PullRequestShort {
+	shortPRs := make([]api.PullRequestShort, len(prs))
+	for i, pr := range prs {
+		shortPRs[i] = api.PullRequestShort{
+			PullRequestId:   pr.ID,
+			PullRequestName: pr.Title,
+			AuthorId:        pr.AuthorID,
+			Status:          api.PullRequestShortStatus(pr.Status),
+		}
+	}
+
+	return shortPRs
+}
+
+func convertReassignmentsToAPI(reassignments []models.Reassignment) []api.Reassignment {
+	result := make([]api.Reassignment, len(reassignments))
+	for i, reassignment := range reassignments {
+		result[i] = api.Reassignment{
+			PullRequestId:    reassignment.PullRequestID,
+			OldReviewers:     nonNil(reassignment.OldReviewers),
+			NewReviewers:     nonNil(reassignment.NewReviewers),
+			MissingReviewers: reassignment.MissingReviewers,
+		}
+	}
+
+	return result
+}
+
+// nonNil нужен, чтобы пустые списки сериализовались в [], а не в null.
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+
+	return ids
+}
+
+func convertAbsenceToAPI(absence *models.Absence) api.UserAbsence {
+	return api.UserAbsence{
+		AbsenceId: absence.ID,
+		UserId:    absence.UserID,
+		StartsAt:  absence.StartsAt,
+		EndsAt:    absence.EndsAt,
+		Reason:    absence.Reason,
+	}
+}
+
+func convertLoginToAPI(login *models.UserLogin) api.UserLogin {
+	return api.UserLogin{
+		Provider: api.LoginProvider(login.Provider),
+		Login:    login.Login,
+		UserId:   login.UserID,
+	}
+}
+
+func convertSubscriptionToAPI(sub *models.Subscription) api.WebhookSubscription {
+	events := make([]api.WebhookEventType, len(sub.Events))
+	for i, event := range sub.Events {
+		events[i] = api.WebhookEventType(event)
+	}
+
+	return api.WebhookSubscription{
+		SubscriptionId: sub.ID,
+		Url:            sub.URL,
+		Events:         events,
+		IsActive:       sub.IsActive,
+	}
+}
+
+func convertEventTypesFromAPI(events []api.WebhookEventType) []models.EventType {
+	result := make([]models.EventType, len(events))
+	for i, event := range events {
+		result[i] = models.EventType(event)
+	}
+
+	return result
+}
+
+func convertDeliveryToAPI(delivery *models.EventDelivery) api.WebhookDelivery {
+	return api.WebhookDelivery{
+		DeliveryId:     delivery.ID,
+		SubscriptionId: delivery.SubscriptionID,
+		EventId:        delivery.EventID,
+		EventType:      api.WebhookEventType(delivery.EventType),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+func convertTokenToAPI(token *models.APIToken) api.ApiToken {
+	return api.ApiToken{
+		TokenId:   token.ID,
+		Name:      token.Name,
+		Role:      api.TokenRole(token.Role),
+		CreatedAt: token.CreatedAt,
+		RevokedAt: token.RevokedAt,
+	}
+}
+
+func convertAuditEntryToAPI(entry *models.AuditEntry) (api.AuditEntry, error) {
+	before, err := decodeAuditSnapshot(entry.Before)
+	if err != nil {
+		return api.AuditEntry{}, err
+	}
+
+	after, err := decodeAuditSnapshot(entry.After)
+	if err != nil {
+		return api.AuditEntry{}, err
+	}
+
+	return api.AuditEntry{
+		AuditId:    entry.ID,
+		Action:     entry.Action,
+		EntityType: api.AuditEntityType(entry.EntityType),
+		EntityId:   entry.EntityID,
+		Actor:      entry.Actor,
+		RequestId:  entry.RequestID,
+		Before:     before,
+		After:      after,
+		CreatedAt:  entry.CreatedAt,
+	}, nil
+}
+
+func decodeAuditSnapshot(data []byte) (*map[string]interface{}, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("decode audit snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+func convertReadinessToAPI(readiness *models.Readiness) api.Readiness {
+	components := make(map[string]api.HealthComponent, len(readiness.Components))
+	for name, component := range readiness.Components {
+		resp := api.HealthComponent{Status: api.HealthStatus(component.Status)}
+		if component.Error != "" {
+			resp.Error = &component.Error
+		}
+		if component.Details != nil {
+			details := map[string]interface{}(component.Details)
+			resp.Details = &details
+		}
+		components[name] = resp
+	}
+
+	return api.Readiness{
+		Status:       api.HealthStatus(readiness.Status),
+		ShuttingDown: readiness.ShuttingDown,
+		Components:   components,
 	}
 }
@@ -1,65 +1,150 @@
 package handlers
 
 import (
+	"errors"
+	"log/slog"
+
 	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
 	"github.com/AntonTsoy/review-pull-request-service/internal/service"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
 
 	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const internalErrorMessage = "internal server error"
+
 type handlers struct {
 	*TeamHandler
 	*UserHandler
 	*PullRequestHandler
+	*StatsHandler
+	*WebhookHandler
+	*SubscriptionHandler
+	*TokenHandler
+	*AuditHandler
+	*HealthHandler
 }
 
-func NewHandlers(service *service.Service) api.ServerInterface {
+func NewHandlers(service *service.Service, cfg *config.Config) api.ServerInterface {
 	return &handlers{
-		TeamHandler:        newTeamHandler(service.TeamService),
-		UserHandler:        newUserHandler(service.UserService, service.PullRequestService),
-		PullRequestHandler: newPullRequestHandler(service.PullRequestService),
+		TeamHandler:         newTeamHandler(service.TeamService),
+		UserHandler:         newUserHandler(service.UserService, service.PullRequestService),
+		PullRequestHandler:  newPullRequestHandler(service.PullRequestService),
+		StatsHandler:        newStatsHandler(service.StatsService),
+		WebhookHandler:      newWebhookHandler(service.WebhookService, cfg.GitHubWebhookSecret, cfg.GitLabWebhookToken),
+		SubscriptionHandler: newSubscriptionHandler(service.SubscriptionService),
+		TokenHandler:        newTokenHandler(service.AuthService),
+		AuditHandler:        newAuditHandler(service.AuditService),
+		HealthHandler:       newHealthHandler(service.HealthService),
 	}
 }
 
+// handleError отвечает клиенту кодом, соответствующим ошибке сервиса, и логирует её вместе
+// с цепочкой обёрток. В ответах 500 текст ошибки заменяется общим сообщением.
 func handleError(c *fiber.Ctx, err error) error {
-	switch err {
-	case apperrors.ErrTeamExists:
+	trace.SpanFromContext(c.UserContext()).RecordError(err)
+
+	respErr := writeError(c, err)
+
+	level := slog.LevelInfo
+	if c.Response().StatusCode() >= fiber.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	slog.LogAttrs(c.UserContext(), level, "request failed", logging.Error(err))
+
+	return respErr
+}
+
+func writeError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrTeamExists):
 		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
 			Error: ErrorMessage{
 				Code:    "TEAM_EXISTS",
 				Message: err.Error(),
 			},
 		})
-	case apperrors.ErrPullRequestExists:
+	case errors.Is(err, apperrors.ErrPullRequestExists):
 		return c.Status(fiber.StatusConflict).JSON(api.ErrorResponse{
 			Error: ErrorMessage{
 				Code:    "PR_EXISTS",
 				Message: err.Error(),
 			},
 		})
-	case apperrors.ErrPullRequestMerged:
+	case errors.Is(err, apperrors.ErrPullRequestMerged), errors.Is(err, apperrors.ErrAlreadyMerged):
 		return c.Status(fiber.StatusConflict).JSON(api.ErrorResponse{
 			Error: ErrorMessage{
 				Code:    "PR_MERGED",
 				Message: err.Error(),
 			},
 		})
-	case apperrors.ErrNotAssigned:
+	case errors.Is(err, apperrors.ErrPullRequestClosed):
+		return c.Status(fiber.StatusConflict).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "PR_CLOSED",
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, apperrors.ErrNotAssigned):
 		return c.Status(fiber.StatusConflict).JSON(api.ErrorResponse{
 			Error: ErrorMessage{
 				Code:    "NOT_ASSIGNED",
 				Message: err.Error(),
 			},
 		})
-	case apperrors.ErrNoCandidate:
+	case errors.Is(err, apperrors.ErrNotApproved):
+		return c.Status(fiber.StatusConflict).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "NOT_APPROVED",
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, apperrors.ErrNoCandidate):
 		return c.Status(fiber.StatusConflict).JSON(api.ErrorResponse{
 			Error: ErrorMessage{
 				Code:    "NO_CANDIDATE",
 				Message: err.Error(),
 			},
 		})
-	case apperrors.ErrNotFound:
+	case errors.Is(err, apperrors.ErrUnknownStrategy),
+		errors.Is(err, apperrors.ErrInvalidReviewers),
+		errors.Is(err, apperrors.ErrInvalidPeriod),
+		errors.Is(err, apperrors.ErrInvalidReviewCap),
+		errors.Is(err, apperrors.ErrNothingToUpdate),
+		errors.Is(err, apperrors.ErrInvalidReview),
+		errors.Is(err, apperrors.ErrUnknownProvider),
+		errors.Is(err, apperrors.ErrInvalidURL),
+		errors.Is(err, apperrors.ErrNoEvents),
+		errors.Is(err, apperrors.ErrUnknownEvent),
+		errors.Is(err, apperrors.ErrUnknownRole),
+		errors.Is(err, apperrors.ErrInvalidTokenName),
+		errors.Is(err, apperrors.ErrUnknownEntity):
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, apperrors.ErrInvalidSignature),
+		errors.Is(err, apperrors.ErrInvalidToken),
+		errors.Is(err, apperrors.ErrUnauthenticated):
+		return c.Status(fiber.StatusUnauthorized).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "UNAUTHORIZED",
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, apperrors.ErrForbidden):
+		return c.Status(fiber.StatusForbidden).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "FORBIDDEN",
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, apperrors.ErrNotFound):
 		return c.Status(fiber.StatusNotFound).JSON(api.ErrorResponse{
 			Error: ErrorMessage{
 				Code:    "NOT_FOUND",
@@ -70,7 +155,7 @@ func handleError(c *fiber.Ctx, err error) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(api.ErrorResponse{
 			Error: ErrorMessage{
 				Code:    "INTERNAL_ERROR",
-				Message: err.Error(),
+				Message: internalErrorMessage,
 			},
 		})
 	}
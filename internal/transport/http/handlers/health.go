@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/service"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HealthHandler struct {
+	healthService *service.HealthService
+}
+
+func newHealthHandler(healthService *service.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// GetHealthLive не трогает зависимости: перезапуск процесса не вылечит упавшую БД.
+func (h *HealthHandler) GetHealthLive(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(api.Liveness{Status: api.UP})
+}
+
+func (h *HealthHandler) GetHealthReady(c *fiber.Ctx) error {
+	readiness := h.healthService.Ready(c.UserContext())
+
+	status := fiber.StatusOK
+	if readiness.Status == models.HealthDown {
+		status = fiber.StatusServiceUnavailable
+	}
+
+	return c.Status(status).JSON(convertReadinessToAPI(readiness))
+}
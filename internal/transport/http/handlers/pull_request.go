@@ -28,7 +28,7 @@ func (h *PullRequestHandler) PostPullRequestCreate(c *fiber.Ctx) error {
 		})
 	}
 
-	pr, err := h.prService.Create(c.Context(), &req)
+	pr, err := h.prService.Create(c.UserContext(), &req)
 	if err != nil {
 		return handleError(c, err)
 	}
@@ -47,7 +47,64 @@ func (h *PullRequestHandler) PostPullRequestMerge(c *fiber.Ctx) error {
 		})
 	}
 
-	pr, err := h.prService.Merge(c.Context(), req.PullRequestId)
+	pr, err := h.prService.Merge(c.UserContext(), req.PullRequestId)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(PullRequestResponse{Pr: convertPRToAPI(pr)})
+}
+
+func (h *PullRequestHandler) PostPullRequestClose(c *fiber.Ctx) error {
+	var req api.PostPullRequestCloseJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	pr, err := h.prService.Close(c.UserContext(), req.PullRequestId)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(PullRequestResponse{Pr: convertPRToAPI(pr)})
+}
+
+func (h *PullRequestHandler) PostPullRequestReopen(c *fiber.Ctx) error {
+	var req api.PostPullRequestReopenJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	pr, err := h.prService.Reopen(c.UserContext(), req.PullRequestId)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(PullRequestResponse{Pr: convertPRToAPI(pr)})
+}
+
+func (h *PullRequestHandler) PostPullRequestReview(c *fiber.Ctx) error {
+	var req api.PostPullRequestReviewJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	pr, err := h.prService.SubmitReview(c.UserContext(), req.PullRequestId, req.ReviewerId, string(req.State), req.Comment)
 	if err != nil {
 		return handleError(c, err)
 	}
@@ -66,7 +123,7 @@ func (h *PullRequestHandler) PostPullRequestReassign(c *fiber.Ctx) error {
 		})
 	}
 
-	pr, replacedBy, err := h.prService.Reassign(c.Context(), req.PullRequestId, req.OldUserId)
+	pr, replacedBy, err := h.prService.Reassign(c.UserContext(), req.PullRequestId, req.OldUserId)
 	if err != nil {
 		return handleError(c, err)
 	}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/service"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatsHandler struct {
+	statsService *service.StatsService
+}
+
+func newStatsHandler(statsService *service.StatsService) *StatsHandler {
+	return &StatsHandler{
+		statsService: statsService,
+	}
+}
+
+func (h *StatsHandler) GetStatsAssignments(c *fiber.Ctx, params api.GetStatsAssignmentsParams) error {
+	filter := models.StatsFilter{
+		From:     params.From,
+		To:       params.To,
+		TeamName: params.TeamName,
+	}
+
+	stats, err := h.statsService.GetAssignments(c.UserContext(), filter)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	assignments := make([]api.UserAssignmentStats, len(stats))
+	for i, s := range stats {
+		assignments[i] = api.UserAssignmentStats{
+			UserId:   s.UserID,
+			Username: s.Username,
+			TeamName: s.TeamName,
+			Total:    s.Total,
+			Open:     s.Open,
+			Merged:   s.Merged,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(StatsAssignmentsResponse{Assignments: assignments})
+}
+
+func (h *StatsHandler) GetStatsPullRequests(c *fiber.Ctx, params api.GetStatsPullRequestsParams) error {
+	filter := models.StatsFilter{
+		From:     params.From,
+		To:       params.To,
+		TeamName: params.TeamName,
+	}
+
+	stats, err := h.statsService.GetPullRequests(c.UserContext(), filter)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	teams := make([]api.TeamPullRequestStats, len(stats))
+	for i, s := range stats {
+		teams[i] = api.TeamPullRequestStats{
+			TeamName:              s.TeamName,
+			Open:                  s.Open,
+			Merged:                s.Merged,
+			WithoutReviewers:      s.WithoutReviewers,
+			AvgTimeToMergeSeconds: s.AvgTimeToMerge,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(StatsPullRequestsResponse{Teams: teams})
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/service"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SubscriptionHandler struct {
+	subscriptionService *service.SubscriptionService
+}
+
+func newSubscriptionHandler(subscriptionService *service.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{subscriptionService: subscriptionService}
+}
+
+func (h *SubscriptionHandler) GetWebhooksSubscriptions(c *fiber.Ctx) error {
+	subs, err := h.subscriptionService.List(c.UserContext())
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := SubscriptionListResponse{Subscriptions: make([]api.WebhookSubscription, len(subs))}
+	for i := range subs {
+		resp.Subscriptions[i] = convertSubscriptionToAPI(&subs[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *SubscriptionHandler) PostWebhooksSubscriptions(c *fiber.Ctx) error {
+	var req api.PostWebhooksSubscriptionsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	sub := &models.Subscription{
+		URL:      req.Url,
+		Events:   convertEventTypesFromAPI(req.Events),
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+	if req.Secret != nil {
+		sub.Secret = *req.Secret
+	}
+
+	sub, err := h.subscriptionService.Create(c.UserContext(), sub)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := SubscriptionCreateResponse{
+		Subscription: convertSubscriptionToAPI(sub),
+		Secret:       sub.Secret,
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (h *SubscriptionHandler) PostWebhooksSubscriptionsUpdate(c *fiber.Ctx) error {
+	var req api.PostWebhooksSubscriptionsUpdateJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	var events *[]models.EventType
+	if req.Events != nil {
+		converted := convertEventTypesFromAPI(*req.Events)
+		events = &converted
+	}
+
+	sub, err := h.subscriptionService.Update(c.UserContext(), req.SubscriptionId, req.Url, events, req.IsActive)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(SubscriptionResponse{Subscription: convertSubscriptionToAPI(sub)})
+}
+
+func (h *SubscriptionHandler) PostWebhooksSubscriptionsDelete(c *fiber.Ctx) error {
+	var req api.PostWebhooksSubscriptionsDeleteJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if err := h.subscriptionService.Delete(c.UserContext(), req.SubscriptionId); err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(SubscriptionDeleteResponse{SubscriptionId: req.SubscriptionId})
+}
+
+func (h *SubscriptionHandler) GetWebhooksSubscriptionsDeliveries(
+	c *fiber.Ctx,
+	params api.GetWebhooksSubscriptionsDeliveriesParams,
+) error {
+	deliveries, err := h.subscriptionService.GetDeliveries(c.UserContext(), params.SubscriptionId, params.Limit)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := SubscriptionDeliveriesResponse{
+		SubscriptionId: params.SubscriptionId,
+		Deliveries:     make([]api.WebhookDelivery, len(deliveries)),
+	}
+	for i := range deliveries {
+		resp.Deliveries[i] = convertDeliveryToAPI(&deliveries[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
@@ -28,23 +28,62 @@ func (h *TeamHandler) PostTeamAdd(c *fiber.Ctx) error {
 		})
 	}
 
-	err := h.teamService.CreateTeam(c.Context(), req)
+	team, err := h.teamService.CreateTeam(c.UserContext(), req)
 	if err != nil {
 		return handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(TeamResponse{Team: req})
+	return c.Status(fiber.StatusOK).JSON(TeamResponse{Team: *team})
 }
 
 func (h *TeamHandler) GetTeamGet(c *fiber.Ctx, params api.GetTeamGetParams) error {
-	members, err := h.teamService.GetTeam(c.Context(), params.TeamName)
+	team, err := h.teamService.GetTeam(c.UserContext(), params.TeamName)
 	if err != nil {
 		return handleError(c, err)
 	}
 
-	resp := api.Team{
-		TeamName: params.TeamName,
-		Members:  members,
+	return c.Status(fiber.StatusOK).JSON(team)
+}
+
+func (h *TeamHandler) PostTeamSettings(c *fiber.Ctx) error {
+	var req api.PostTeamSettingsJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	settings, err := h.teamService.UpdateSettings(c.UserContext(), req)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(TeamSettingsResponse{Settings: settings})
+}
+
+func (h *TeamHandler) PostTeamDeactivateMembers(c *fiber.Ctx) error {
+	var req api.PostTeamDeactivateMembersJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	deactivated, reassignments, err := h.teamService.DeactivateMembers(c.UserContext(), req.TeamName, req.UserIds)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := TeamDeactivateMembersResponse{
+		TeamName:           req.TeamName,
+		DeactivatedUserIds: nonNil(deactivated),
+		Reassignments:      convertReassignmentsToAPI(reassignments),
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
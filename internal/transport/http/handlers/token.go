@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"github.com/AntonTsoy/review-pull-request-service/internal/service"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TokenHandler struct {
+	authService *service.AuthService
+}
+
+func newTokenHandler(authService *service.AuthService) *TokenHandler {
+	return &TokenHandler{authService: authService}
+}
+
+func (h *TokenHandler) PostTokensIssue(c *fiber.Ctx) error {
+	var req api.PostTokensIssueJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	token, accessToken, err := h.authService.Issue(c.UserContext(), req.Name, string(req.Role))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := TokenIssueResponse{
+		Token:       convertTokenToAPI(token),
+		AccessToken: accessToken,
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (h *TokenHandler) GetTokensList(c *fiber.Ctx) error {
+	tokens, err := h.authService.List(c.UserContext())
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := TokenListResponse{Tokens: make([]api.ApiToken, len(tokens))}
+	for i := range tokens {
+		resp.Tokens[i] = convertTokenToAPI(&tokens[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *TokenHandler) PostTokensRevoke(c *fiber.Ctx) error {
+	var req api.PostTokensRevokeJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	token, err := h.authService.Revoke(c.UserContext(), req.TokenId)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(TokenResponse{Token: convertTokenToAPI(token)})
+}
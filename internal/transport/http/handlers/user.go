@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"github.com/AntonTsoy/review-pull-request-service/internal/auth"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
 	"github.com/AntonTsoy/review-pull-request-service/internal/service"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
 
@@ -20,24 +22,29 @@ func newUserHandler(userService *service.UserService, prService *service.PullReq
 }
 
 func (h *UserHandler) GetUsersGetReview(c *fiber.Ctx, params api.GetUsersGetReviewParams) error {
-	prs, err := h.prService.GetReviewForUser(c.Context(), params.UserId)
-	if err != nil {
-		return handleError(c, err)
+	var userID string
+	if params.UserId != nil {
+		userID = *params.UserId
+	} else if identity := auth.FromContext(c.UserContext()); identity != nil {
+		userID = identity.UserID
+	}
+	if userID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: "user_id is required unless the request is authenticated with a user JWT",
+			},
+		})
 	}
 
-	shortPRs := make([]api.PullRequestShort, len(prs))
-	for i, pr := range prs {
-		shortPRs[i] = api.PullRequestShort{
-			PullRequestId:   pr.ID,
-			PullRequestName: pr.Title,
-			AuthorId:        pr.AuthorID,
-			Status:          api.PullRequestShortStatus(pr.Status),
-		}
+	prs, err := h.prService.GetReviewForUser(c.UserContext(), userID)
+	if err != nil {
+		return handleError(c, err)
 	}
 
 	resp := UserGetReviewResponse{
-		UserId:       params.UserId,
-		PullRequests: shortPRs,
+		UserId:       userID,
+		PullRequests: convertShortPRsToAPI(prs),
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
@@ -54,10 +61,96 @@ func (h *UserHandler) PostUsersSetIsActive(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.userService.SetIsActive(c.Context(), req.UserId, req.IsActive)
+	reassign := req.ReassignReviews == nil || *req.ReassignReviews
+
+	result, err := h.userService.SetIsActive(c.UserContext(), req.UserId, req.IsActive, reassign)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	resp := UserSetIsActiveResponse{
+		User:           result.User,
+		Reassignments:  convertReassignmentsToAPI(result.Reassignments),
+		PendingReviews: convertShortPRsToAPI(result.PendingReviews),
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *UserHandler) PostUsersUpdate(c *fiber.Ctx) error {
+	var req api.PostUsersUpdateJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	user, err := h.userService.UpdateUser(c.UserContext(), req.UserId, req.Username, req.MaxOpenReviews)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(UserUpdateResponse{User: user})
+}
+
+func (h *UserHandler) PostUsersAddAbsence(c *fiber.Ctx) error {
+	var req api.PostUsersAddAbsenceJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	absence, err := h.userService.AddAbsence(c.UserContext(), &models.Absence{
+		UserID:   req.UserId,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(UserAbsenceResponse{Absence: convertAbsenceToAPI(absence)})
+}
+
+func (h *UserHandler) PostUsersDeleteAbsence(c *fiber.Ctx) error {
+	var req api.PostUsersDeleteAbsenceJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	if err := h.userService.DeleteAbsence(c.UserContext(), req.AbsenceId); err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(UserDeleteAbsenceResponse{AbsenceId: req.AbsenceId})
+}
+
+func (h *UserHandler) GetUsersGetAbsences(c *fiber.Ctx, params api.GetUsersGetAbsencesParams) error {
+	absences, err := h.userService.GetAbsences(c.UserContext(), params.UserId)
 	if err != nil {
 		return handleError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(user)
+	resp := UserGetAbsencesResponse{
+		UserId:   params.UserId,
+		Absences: make([]api.UserAbsence, len(absences)),
+	}
+	for i := range absences {
+		resp.Absences[i] = convertAbsenceToAPI(&absences[i])
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
 }
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/service"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxTitleLength — длина pull_requests.title, более длинные названия из внешних систем обрезаются.
+const maxTitleLength = 100
+
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+	githubSecret   []byte
+	gitlabToken    []byte
+}
+
+func newWebhookHandler(webhookService *service.WebhookService, githubSecret, gitlabToken string) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		githubSecret:   []byte(githubSecret),
+		gitlabToken:    []byte(gitlabToken),
+	}
+}
+
+func (h *WebhookHandler) PostUsersLinkLogin(c *fiber.Ctx) error {
+	var req api.PostUsersLinkLoginJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	login := &models.UserLogin{Provider: string(req.Provider), Login: req.Login, UserID: req.UserId}
+	if err := h.webhookService.LinkLogin(c.UserContext(), login); err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(UserLoginResponse{Login: convertLoginToAPI(login)})
+}
+
+func (h *WebhookHandler) PostUsersUnlinkLogin(c *fiber.Ctx) error {
+	var req api.PostUsersUnlinkLoginJSONRequestBody
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	login, err := h.webhookService.UnlinkLogin(c.UserContext(), string(req.Provider), req.Login)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(UserLoginResponse{Login: convertLoginToAPI(login)})
+}
+
+func (h *WebhookHandler) PostWebhooksGithub(c *fiber.Ctx) error {
+	if !validGitHubSignature(h.githubSecret, c.Body(), c.Get("X-Hub-Signature-256")) {
+		return handleError(c, apperrors.ErrInvalidSignature)
+	}
+
+	deliveryID := c.Get("X-GitHub-Delivery")
+	if deliveryID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: "X-GitHub-Delivery header is required",
+			},
+		})
+	}
+
+	var payload api.PostWebhooksGithubJSONRequestBody
+	if err := json.Unmarshal(c.Body(), &payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	event := githubEvent(c.Get("X-GitHub-Event"), &payload)
+	if event == nil {
+		return c.Status(fiber.StatusOK).JSON(api.WebhookResult{DeliveryId: deliveryID, Result: api.IGNORED})
+	}
+	event.DeliveryID = deliveryID
+
+	result, pr, err := h.webhookService.Process(c.UserContext(), event)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	response := api.WebhookResult{DeliveryId: deliveryID, Result: api.WebhookResultResult(result)}
+	if pr != nil {
+		response.Pr = convertPRToAPI(pr)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+func (h *WebhookHandler) PostWebhooksGitlab(c *fiber.Ctx) error {
+	if !validGitLabToken(h.gitlabToken, c.Get("X-Gitlab-Token")) {
+		return handleError(c, apperrors.ErrInvalidToken)
+	}
+
+	deliveryID := c.Get("X-Gitlab-Event-UUID")
+	if deliveryID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: "X-Gitlab-Event-UUID header is required",
+			},
+		})
+	}
+
+	var payload api.PostWebhooksGitlabJSONRequestBody
+	if err := json.Unmarshal(c.Body(), &payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Error: ErrorMessage{
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	}
+
+	event := gitlabEvent(c.Get("X-Gitlab-Event"), &payload)
+	if event == nil {
+		return c.Status(fiber.StatusOK).JSON(api.WebhookResult{DeliveryId: deliveryID, Result: api.IGNORED})
+	}
+	event.DeliveryID = deliveryID
+
+	result, pr, err := h.webhookService.Process(c.UserContext(), event)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	response := api.WebhookResult{DeliveryId: deliveryID, Result: api.WebhookResultResult(result)}
+	if pr != nil {
+		response.Pr = convertPRToAPI(pr)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// validGitHubSignature сверяет X-Hub-Signature-256 (sha256=<hex HMAC тела>) с подписью,
+// посчитанной по секрету. Без настроенного секрета ни одна подпись не считается верной.
+func validGitHubSignature(secret, body []byte, header string) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if len(secret) == 0 || !ok {
+		return false
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// githubEvent переводит событие GitHub в операцию над PR, nil — событие сервису не интересно.
+func githubEvent(eventType string, payload *api.GitHubWebhookPayload) *models.WebhookEvent {
+	if payload.Action == nil || payload.PullRequest == nil {
+		return nil
+	}
+
+	pr := payload.PullRequest
+	event := &models.WebhookEvent{
+		Provider:      models.ProviderGitHub,
+		PullRequestID: "github-" + strconv.FormatInt(pr.Id, 10),
+		Title:         truncate(pr.Title, maxTitleLength),
+		AuthorLogin:   pr.User.Login,
+	}
+
+	switch eventType + "." + *payload.Action {
+	case "pull_request.opened":
+		event.Action = models.WebhookOpened
+	case "pull_request.closed":
+		event.Action = models.WebhookClosed
+		if pr.Merged != nil && *pr.Merged {
+			event.Action = models.WebhookMerged
+		}
+	case "pull_request.reopened":
+		event.Action = models.WebhookReopened
+	case "pull_request_review.submitted":
+		if payload.Review == nil {
+			return nil
+		}
+
+		switch payload.Review.State {
+		case "approved":
+			event.ReviewState = models.ReviewApproved
+		case "changes_requested":
+			event.ReviewState = models.ReviewChangesRequested
+		default:
+			return nil
+		}
+		event.Action = models.WebhookReviewed
+		event.ReviewerLogin = payload.Review.User.Login
+		if payload.Review.Body != nil && *payload.Review.Body != "" {
+			event.Comment = payload.Review.Body
+		}
+	default:
+		return nil
+	}
+
+	return event
+}
+
+// validGitLabToken сравнивает X-Gitlab-Token с настроенным токеном за постоянное время.
+// Без настроенного токена ни один запрос не считается верным.
+func validGitLabToken(token []byte, header string) bool {
+	if len(token) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(token, []byte(header)) == 1
+}
+
+// gitlabEvent переводит событие Merge Request Hook в операцию над PR, nil — событие сервису не интересно.
+// GitLab не передаёт логин автора MR, поэтому все действия приписываются пользователю из поля user.
+// merge, как и merge из GitHub, записывается без проверки одобрений: MR уже смёржен в GitLab.
+func gitlabEvent(eventType string, payload *api.GitLabWebhookPayload) *models.WebhookEvent {
+	if eventType != "Merge Request Hook" || payload.ObjectAttributes == nil ||
+		payload.ObjectAttributes.Action == nil || payload.User == nil {
+		return nil
+	}
+
+	mr := payload.ObjectAttributes
+	event := &models.WebhookEvent{
+		Provider:      models.ProviderGitLab,
+		PullRequestID: "gitlab-" + strconv.FormatInt(mr.Id, 10),
+		Title:         truncate(mr.Title, maxTitleLength),
+	}
+
+	switch *mr.Action {
+	case "open":
+		event.Action = models.WebhookOpened
+		event.AuthorLogin = payload.User.Username
+	case "merge":
+		event.Action = models.WebhookMerged
+	case "close":
+		event.Action = models.WebhookClosed
+	case "reopen":
+		event.Action = models.WebhookReopened
+	case "approved", "approval":
+		event.Action = models.WebhookReviewed
+		event.ReviewState = models.ReviewApproved
+		event.ReviewerLogin = payload.User.Username
+	default:
+		return nil
+	}
+
+	return event
+}
+
+func truncate(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+
+	return string(runes[:maxRunes])
+}
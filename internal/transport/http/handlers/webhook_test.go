@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/config"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+	"github.com/AntonTsoy/review-pull-request-service/internal/repository/memory"
+	"github.com/AntonTsoy/review-pull-request-service/internal/service"
+	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const testGitHubSecret = "webhook-secret"
+
+func githubSignature(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidGitHubSignature(t *testing.T) {
+	body := `{"action":"opened"}`
+
+	tests := []struct {
+		name   string
+		secret string
+		body   string
+		header string
+		want   bool
+	}{
+		{name: "valid", secret: testGitHubSecret, body: body, header: githubSignature(testGitHubSecret, body), want: true},
+		{name: "tampered body", secret: testGitHubSecret, body: `{"action":"closed"}`, header: githubSignature(testGitHubSecret, body)},
+		{name: "other secret", secret: testGitHubSecret, body: body, header: githubSignature("other", body)},
+		{name: "empty secret", secret: "", body: body, header: githubSignature("", body)},
+		{name: "missing header", secret: testGitHubSecret, body: body, header: ""},
+		{name: "no sha256 prefix", secret: testGitHubSecret, body: body, header: strings.TrimPrefix(githubSignature(testGitHubSecret, body), "sha256=")},
+		{name: "not hex", secret: testGitHubSecret, body: body, header: "sha256=zz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validGitHubSignature([]byte(tt.secret), []byte(tt.body), tt.header); got != tt.want {
+				t.Errorf("validGitHubSignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		payload   string
+		want      models.WebhookAction
+		wantState models.ReviewState
+	}{
+		{
+			name:      "opened",
+			eventType: "pull_request",
+			payload:   `{"action":"opened","pull_request":{"id":1,"title":"t","user":{"login":"alice"}}}`,
+			want:      models.WebhookOpened,
+		},
+		{
+			name:      "closed and merged",
+			eventType: "pull_request",
+			payload:   `{"action":"closed","pull_request":{"id":1,"title":"t","merged":true,"user":{"login":"alice"}}}`,
+			want:      models.WebhookMerged,
+		},
+		{
+			name:      "closed without merge",
+			eventType: "pull_request",
+			payload:   `{"action":"closed","pull_request":{"id":1,"title":"t","merged":false,"user":{"login":"alice"}}}`,
+			want:      models.WebhookClosed,
+		},
+		{
+			name:      "closed without merged flag",
+			eventType: "pull_request",
+			payload:   `{"action":"closed","pull_request":{"id":1,"title":"t","user":{"login":"alice"}}}`,
+			want:      models.WebhookClosed,
+		},
+		{
+			name:      "reopened",
+			eventType: "pull_request",
+			payload:   `{"action":"reopened","pull_request":{"id":1,"title":"t","user":{"login":"alice"}}}`,
+			want:      models.WebhookReopened,
+		},
+		{
+			name:      "review approved",
+			eventType: "pull_request_review",
+			payload: `{"action":"submitted","pull_request":{"id":1,"title":"t","user":{"login":"alice"}},` +
+				`"review":{"state":"approved","user":{"login":"bob"}}}`,
+			want:      models.WebhookReviewed,
+			wantState: models.ReviewApproved,
+		},
+		{
+			name:      "review commented",
+			eventType: "pull_request_review",
+			payload: `{"action":"submitted","pull_request":{"id":1,"title":"t","user":{"login":"alice"}},` +
+				`"review":{"state":"commented","user":{"login":"bob"}}}`,
+		},
+		{
+			name:      "other action",
+			eventType: "pull_request",
+			payload:   `{"action":"labeled","pull_request":{"id":1,"title":"t","user":{"login":"alice"}}}`,
+		},
+		{
+			name:      "other event",
+			eventType: "push",
+			payload:   `{"action":"opened","pull_request":{"id":1,"title":"t","user":{"login":"alice"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload api.GitHubWebhookPayload
+			if err := json.Unmarshal([]byte(tt.payload), &payload); err != nil {
+				t.Fatalf("unmarshal payload: %v", err)
+			}
+
+			event := githubEvent(tt.eventType, &payload)
+			if tt.want == "" {
+				if event != nil {
+					t.Fatalf("githubEvent() = %+v, want nil", event)
+				}
+				return
+			}
+
+			if event == nil {
+				t.Fatalf("githubEvent() = nil, want %s", tt.want)
+			}
+			if event.Action != tt.want {
+				t.Errorf("action = %s, want %s", event.Action, tt.want)
+			}
+			if event.ReviewState != tt.wantState {
+				t.Errorf("review state = %s, want %s", event.ReviewState, tt.wantState)
+			}
+			if event.PullRequestID != "github-1" {
+				t.Errorf("pull request id = %s, want github-1", event.PullRequestID)
+			}
+		})
+	}
+}
+
+// newWebhookTestApp поднимает обработчики вебхуков поверх хранилища в памяти с командой из двух
+// пользователей; alice привязана к GitHub и GitLab.
+func newWebhookTestApp(t *testing.T, githubSecret, gitlabToken string, requireApprovals bool) *fiber.App {
+	t.Helper()
+
+	svc := service.NewService(memory.NewStore(), memory.NewRepository(), &config.Config{})
+	ctx := context.Background()
+
+	_, err := svc.TeamService.CreateTeam(ctx, api.Team{
+		TeamName:         "backend",
+		RequireApprovals: &requireApprovals,
+		Members: []api.TeamMember{
+			{UserId: "u1", Username: "alice", IsActive: true},
+			{UserId: "u2", Username: "bob", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	for _, provider := range []models.Provider{models.ProviderGitHub, models.ProviderGitLab} {
+		login := &models.UserLogin{Provider: provider, Login: "alice", UserID: "u1"}
+		if err = svc.WebhookService.LinkLogin(ctx, login); err != nil {
+			t.Fatalf("link %s login: %v", provider, err)
+		}
+	}
+
+	h := newWebhookHandler(svc.WebhookService, githubSecret, gitlabToken)
+	app := fiber.New()
+	app.Post("/webhooks/github", h.PostWebhooksGithub)
+	app.Post("/webhooks/gitlab", h.PostWebhooksGitlab)
+
+	return app
+}
+
+func postGitHub(t *testing.T, app *fiber.App, event, deliveryID, body, signature string) (int, api.WebhookResult) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", deliveryID)
+	req.Header.Set("X-Hub-Signature-256", signature)
+
+	return doWebhook(t, app, req)
+}
+
+func doWebhook(t *testing.T, app *fiber.App, req *http.Request) (int, api.WebhookResult) {
+	t.Helper()
+
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+
+	var result api.WebhookResult
+	if resp.StatusCode == http.StatusOK {
+		if err = json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("unmarshal response %s: %v", data, err)
+		}
+	}
+
+	return resp.StatusCode, result
+}
+
+func TestPostWebhooksGithubSignature(t *testing.T) {
+	body := `{"action":"opened","pull_request":{"id":7,"title":"t","user":{"login":"alice"}}}`
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		want      int
+	}{
+		{name: "valid signature", secret: testGitHubSecret, signature: githubSignature(testGitHubSecret, body), want: http.StatusOK},
+		{name: "tampered body", secret: testGitHubSecret, signature: githubSignature(testGitHubSecret, body+" "), want: http.StatusUnauthorized},
+		{name: "missing signature", secret: testGitHubSecret, signature: "", want: http.StatusUnauthorized},
+		{name: "secret not configured", secret: "", signature: githubSignature("", body), want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newWebhookTestApp(t, tt.secret, "", false)
+
+			status, _ := postGitHub(t, app, "pull_request", "delivery-1", body, tt.signature)
+			if status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostWebhooksGithubReplay(t *testing.T) {
+	app := newWebhookTestApp(t, testGitHubSecret, "", true)
+
+	opened := `{"action":"opened","pull_request":{"id":7,"title":"t","user":{"login":"alice"}}}`
+	merged := `{"action":"closed","pull_request":{"id":7,"title":"t","merged":true,"user":{"login":"alice"}}}`
+
+	steps := []struct {
+		name       string
+		deliveryID string
+		body       string
+		want       api.WebhookResultResult
+		wantStatus api.PullRequestStatus
+	}{
+		{name: "opened", deliveryID: "d1", body: opened, want: api.PROCESSED, wantStatus: api.PullRequestStatusOPEN},
+		{name: "opened replay", deliveryID: "d1", body: opened, want: api.DUPLICATE},
+		// в команде включён require_approvals, но merge в GitHub уже произошёл
+		{name: "merged without approvals", deliveryID: "d2", body: merged, want: api.PROCESSED, wantStatus: api.PullRequestStatusMERGED},
+		{name: "merged replay", deliveryID: "d2", body: merged, want: api.DUPLICATE},
+	}
+
+	for _, step := range steps {
+		status, result := postGitHub(t, app, "pull_request", step.deliveryID, step.body, githubSignature(testGitHubSecret, step.body))
+		if status != http.StatusOK {
+			t.Fatalf("%s: status = %d, want 200", step.name, status)
+		}
+		if result.Result != step.want {
+			t.Fatalf("%s: result = %s, want %s", step.name, result.Result, step.want)
+		}
+		if step.wantStatus != "" && (result.Pr == nil || result.Pr.Status != step.wantStatus) {
+			t.Fatalf("%s: pr = %+v, want status %s", step.name, result.Pr, step.wantStatus)
+		}
+	}
+}
+
+func TestPostWebhooksGithubConcurrentReplay(t *testing.T) {
+	app := newWebhookTestApp(t, testGitHubSecret, "", false)
+
+	body := `{"action":"opened","pull_request":{"id":7,"title":"t","user":{"login":"alice"}}}`
+	signature := githubSignature(testGitHubSecret, body)
+
+	const replays = 8
+	results := make(chan api.WebhookResultResult, replays)
+	for range replays {
+		go func() {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-GitHub-Event", "pull_request")
+			req.Header.Set("X-GitHub-Delivery", "d1")
+			req.Header.Set("X-Hub-Signature-256", signature)
+
+			// t.Fatal нельзя вызывать из другой горутины, поэтому ошибки приходят пустым результатом
+			var result api.WebhookResult
+			if resp, err := app.Test(req, -1); err == nil {
+				_ = json.NewDecoder(resp.Body).Decode(&result)
+				resp.Body.Close()
+			}
+			results <- result.Result
+		}()
+	}
+
+	processed := 0
+	for range replays {
+		switch result := <-results; result {
+		case api.PROCESSED:
+			processed++
+		case api.DUPLICATE:
+		default:
+			t.Errorf("result = %q, want PROCESSED or DUPLICATE", result)
+		}
+	}
+	if processed != 1 {
+		t.Errorf("processed %d times, want exactly once", processed)
+	}
+}
+
+func TestGitLabEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType string
+		action    string
+		want      models.WebhookAction
+	}{
+		{name: "open", eventType: "Merge Request Hook", action: "open", want: models.WebhookOpened},
+		{name: "merge", eventType: "Merge Request Hook", action: "merge", want: models.WebhookMerged},
+		{name: "close", eventType: "Merge Request Hook", action: "close", want: models.WebhookClosed},
+		{name: "reopen", eventType: "Merge Request Hook", action: "reopen", want: models.WebhookReopened},
+		{name: "approved", eventType: "Merge Request Hook", action: "approved", want: models.WebhookReviewed},
+		{name: "update", eventType: "Merge Request Hook", action: "update"},
+		{name: "other event", eventType: "Push Hook", action: "merge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := &api.GitLabWebhookPayload{
+				ObjectAttributes: &api.GitLabMergeRequest{Id: 1, Title: "t", Action: &tt.action},
+				User:             &api.GitLabUser{Username: "alice"},
+			}
+
+			event := gitlabEvent(tt.eventType, payload)
+			switch {
+			case tt.want == "" && event != nil:
+				t.Fatalf("gitlabEvent() = %+v, want nil", event)
+			case tt.want != "" && event == nil:
+				t.Fatalf("gitlabEvent() = nil, want %s", tt.want)
+			case event != nil && event.Action != tt.want:
+				t.Errorf("action = %s, want %s", event.Action, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostWebhooksGitlabMergeWithoutApprovals(t *testing.T) {
+	const token = "gitlab-token"
+	app := newWebhookTestApp(t, "", token, true)
+
+	steps := []struct {
+		name       string
+		deliveryID string
+		action     string
+		want       api.PullRequestStatus
+	}{
+		{name: "open", deliveryID: "g1", action: "open", want: api.PullRequestStatusOPEN},
+		// в команде включён require_approvals, но MR в GitLab уже смёржен
+		{name: "merge", deliveryID: "g2", action: "merge", want: api.PullRequestStatusMERGED},
+	}
+
+	for _, step := range steps {
+		body := `{"object_kind":"merge_request","user":{"username":"alice"},` +
+			`"object_attributes":{"id":7,"iid":1,"title":"t","action":"` + step.action + `"}}`
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+		req.Header.Set("X-Gitlab-Event-UUID", step.deliveryID)
+		req.Header.Set("X-Gitlab-Token", token)
+
+		status, result := doWebhook(t, app, req)
+		if status != http.StatusOK {
+			t.Fatalf("%s: status = %d, want 200", step.name, status)
+		}
+		if result.Result != api.PROCESSED || result.Pr == nil || result.Pr.Status != step.want {
+			t.Fatalf("%s: result = %s, pr = %+v, want PROCESSED with status %s", step.name, result.Result, result.Pr, step.want)
+		}
+	}
+}
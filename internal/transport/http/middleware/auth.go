@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/apperrors"
+	"github.com/AntonTsoy/review-pull-request-service/internal/auth"
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+	"github.com/AntonTsoy/review-pull-request-service/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// internalErrorMessage заменяет текст внутренних ошибок в ответах 500: подробности пишутся только в лог.
+const internalErrorMessage = "internal server error"
+
+// Authenticator проверяет bearer-токен и возвращает его владельца. ErrUnauthenticated означает,
+// что токен этому Authenticator не знаком, ErrForbidden — что токен действителен, но роли в сервисе не даёт.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*auth.Identity, error)
+}
+
+// publicRoutes открыты без токена: вебхуки проверяют собственную подпись,
+// /metrics забирает Prometheus, а /health/* — пробы оркестратора и балансировщика.
+var publicRoutes = map[string]bool{
+	"POST /webhooks/github": true,
+	"POST /webhooks/gitlab": true,
+	"GET /openapi.yml":      true,
+	"GET /docs":             true,
+	"GET /metrics":          true,
+	"GET /health/live":      true,
+	"GET /health/ready":     true,
+}
+
+// userRoutes доступны токенам с ролью USER. Все остальные маршруты — только ADMIN.
+var userRoutes = map[string]bool{
+	"GET /users/getReview": true,
+	"GET /team/get":        true,
+}
+
+// Auth пускает запрос дальше, только если в Authorization передан действующий токен
+// с ролью, которой разрешён маршрут. Authenticator-ы опрашиваются по порядку, пока
+// один из них не узнает токен; личность вызывающего кладётся в контекст запроса.
+func Auth(authenticators ...Authenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		route := routeKey(c)
+		if publicRoutes[route] {
+			return c.Next()
+		}
+
+		token, ok := bearerToken(c.Get(fiber.HeaderAuthorization))
+		if !ok {
+			return unauthenticated(c)
+		}
+
+		identity, err := authenticate(c.UserContext(), authenticators, token)
+		if errors.Is(err, apperrors.ErrUnauthenticated) {
+			return unauthenticated(c)
+		}
+		if errors.Is(err, apperrors.ErrForbidden) {
+			return forbidden(c)
+		}
+		if err != nil {
+			slog.ErrorContext(c.UserContext(), "authentication failed", logging.Error(err))
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": fiber.Map{
+					"code":    "INTERNAL_ERROR",
+					"message": internalErrorMessage,
+				},
+			})
+		}
+
+		if identity.Role != models.RoleAdmin && !userRoutes[route] {
+			return forbidden(c)
+		}
+
+		c.Locals(auth.ContextKey, identity)
+
+		return c.Next()
+	}
+}
+
+// routeKey строит ключ «МЕТОД путь»; HEAD обслуживается GET-маршрутами, поэтому проверяется как GET.
+func routeKey(c *fiber.Ctx) string {
+	method := c.Method()
+	if method == fiber.MethodHead {
+		method = fiber.MethodGet
+	}
+
+	return method + " " + c.Path()
+}
+
+func authenticate(ctx context.Context, authenticators []Authenticator, token string) (*auth.Identity, error) {
+	for _, authenticator := range authenticators {
+		identity, err := authenticator.Authenticate(ctx, token)
+		if errors.Is(err, apperrors.ErrUnauthenticated) {
+			continue
+		}
+
+		return identity, err
+	}
+
+	return nil, apperrors.ErrUnauthenticated
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func unauthenticated(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"error": fiber.Map{
+			"code":    "UNAUTHORIZED",
+			"message": apperrors.ErrUnauthenticated.Error(),
+		},
+	})
+}
+
+func forbidden(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"error": fiber.Map{
+			"code":    "FORBIDDEN",
+			"message": apperrors.ErrForbidden.Error(),
+		},
+	})
+}
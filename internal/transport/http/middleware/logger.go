@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/auth"
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// requestIDs — идентификаторы пользователей и PR, которые API принимает в теле или query.
+type requestIDs struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	AuthorID      string `json:"author_id"`
+	OldUserID     string `json:"old_user_id"`
+}
+
+// Logger пишет строку о каждом запросе: id запроса, маршрут, статус, время ответа, вызывающего
+// и id пользователей и PR из запроса. Id запроса и сущностей попадают и во все строки,
+// которые сервисы логируют в рамках этого запроса.
+func Logger() fiber.Handler {
+	routes := &routeSet{}
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		attrs := &logging.Attrs{}
+		c.Locals(logging.ContextKey{}, attrs)
+		if requestID, ok := c.Locals("requestid").(string); ok {
+			attrs.Add(slog.String("request_id", strings.Clone(requestID)))
+		}
+		attrs.Add(idAttrs(c)...)
+
+		err := c.Next()
+
+		status := responseStatus(c, err)
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		line := []slog.Attr{
+			slog.String("method", c.Method()),
+			slog.String("route", routes.template(c)),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		}
+		if identity := auth.FromContext(c.Context()); identity != nil {
+			line = append(line, slog.String("actor", identity.Subject))
+		}
+
+		slog.LogAttrs(c.Context(), level, "request", line...)
+
+		return err
+	}
+}
+
+func idAttrs(c *fiber.Ctx) []slog.Attr {
+	var ids requestIDs
+	if body := bytes.TrimSpace(c.Body()); bytes.HasPrefix(body, []byte("{")) {
+		_ = json.Unmarshal(body, &ids)
+	}
+	if ids.PullRequestID == "" {
+		ids.PullRequestID = strings.Clone(c.Query("pull_request_id"))
+	}
+	if ids.UserID == "" {
+		ids.UserID = strings.Clone(c.Query("user_id"))
+	}
+
+	var attrs []slog.Attr
+	for _, id := range []struct{ key, value string }{
+		{"pull_request_id", ids.PullRequestID},
+		{"user_id", ids.UserID},
+		{"author_id", ids.AuthorID},
+		{"old_user_id", ids.OldUserID},
+	} {
+		if id.value != "" {
+			attrs = append(attrs, slog.String(id.key, id.value))
+		}
+	}
+
+	return attrs
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/metrics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics записывает время ответа по шаблону маршрута и статусу.
+func Metrics() fiber.Handler {
+	routes := &routeSet{}
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		metrics.ObserveHTTPRequest(strings.Clone(c.Method()), routes.template(c), responseStatus(c, err),
+			time.Since(start).Seconds())
+
+		return err
+	}
+}
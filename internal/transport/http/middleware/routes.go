@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// unmatchedRoute — метка для запросов к неизвестным путям, чтобы сканеры
+// не плодили ряды метрик и имена спанов с произвольными путями.
+const unmatchedRoute = "unmatched"
+
+// routeSet определяет шаблон маршрута запроса после того, как его обработала цепочка.
+type routeSet struct {
+	once  sync.Once
+	paths map[string]bool
+}
+
+// template возвращает путь маршрута. Если ответ дала middleware (например, 401 из Auth),
+// c.Route() указывает на неё, поэтому путь запроса сверяется со списком маршрутов приложения.
+func (r *routeSet) template(c *fiber.Ctx) string {
+	// маршруты известны только после регистрации всех обработчиков, поэтому собираются при первом запросе
+	r.once.Do(func() {
+		r.paths = make(map[string]bool)
+		for _, route := range c.App().GetRoutes(true) {
+			r.paths[route.Path] = true
+		}
+	})
+
+	if route := c.Route().Path; r.paths[route] {
+		return route
+	}
+	if r.paths[c.Path()] {
+		return strings.Clone(c.Path())
+	}
+
+	return unmatchedRoute
+}
+
+// responseStatus — статус ответа с учётом ошибки, которую ErrorHandler fiber превратит в ответ позже.
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
+	"github.com/AntonTsoy/review-pull-request-service/internal/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDAttribute связывает span с X-Request-ID, который пишется в лог запросов.
+// В обратную сторону связь держит trace_id в строках лога.
+const requestIDAttribute = attribute.Key("http.request.id")
+
+// Tracing открывает span запроса, продолжая трассировку из входящего traceparent, и возвращает
+// traceparent в ответе. Span кладётся в UserContext, поэтому middleware ставится после Timeout,
+// а обработчики передают в сервисы c.UserContext().
+func Tracing() fiber.Handler {
+	routes := &routeSet{}
+
+	return func(c *fiber.Ctx) error {
+		propagator := otel.GetTextMapPropagator()
+		ctx := propagator.Extract(c.UserContext(), headerCarrier{c})
+
+		// строки fiber ссылаются на буферы fasthttp, которые переиспользуются после ответа,
+		// а span живёт до экспорта, поэтому значения атрибутов копируются
+		method := strings.Clone(c.Method())
+		ctx, span := tracing.Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.URLPath(strings.Clone(c.Path())),
+			),
+		)
+		defer span.End()
+
+		if requestID, ok := c.Locals("requestid").(string); ok {
+			span.SetAttributes(requestIDAttribute.String(strings.Clone(requestID)))
+		}
+		if span.SpanContext().IsValid() {
+			logging.Add(ctx, slog.String("trace_id", span.SpanContext().TraceID().String()))
+		}
+
+		c.SetUserContext(ctx)
+		propagator.Inject(ctx, headerCarrier{c})
+
+		err := c.Next()
+
+		route := routes.template(c)
+		status := responseStatus(c, err)
+		span.SetName(method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+		if err != nil {
+			span.RecordError(err)
+		}
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+
+		return err
+	}
+}
+
+// headerCarrier читает trace-заголовки из запроса и пишет их в ответ.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	headers := h.c.GetReqHeaders()
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -2,34 +2,45 @@ package server
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"os"
 	"time"
 
+	"github.com/AntonTsoy/review-pull-request-service/internal/logging"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/api"
 	"github.com/AntonTsoy/review-pull-request-service/internal/transport/http/middleware"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type Server struct {
 	app *fiber.App
 }
 
-func New(handlers api.ServerInterface) *Server {
+// New собирает приложение. Без authenticators API доступно без токенов.
+func New(handlers api.ServerInterface, authenticators ...middleware.Authenticator) *Server {
 	app := fiber.New(fiber.Config{
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 5 * time.Second,
-		IdleTimeout:  10 * time.Second,
+		ReadTimeout:           5 * time.Second,
+		WriteTimeout:          5 * time.Second,
+		IdleTimeout:           10 * time.Second,
+		DisableStartupMessage: true,
+		ErrorHandler:          errorHandler,
 	})
 
 	app.Use(requestid.New())
-	app.Use(logger.New(logger.Config{
-		Format: "${pid} ${locals:requestid} ${status} - ${method} ${path}\n",
-	}))
+	app.Use(middleware.Metrics())
+	app.Use(middleware.Logger())
 
 	app.Use(middleware.Timeout(3 * time.Second))
+	app.Use(middleware.Tracing())
+
+	if len(authenticators) > 0 {
+		app.Use(middleware.Auth(authenticators...))
+	}
 
 	api.RegisterHandlers(app, handlers)
 
@@ -38,6 +49,19 @@ func New(handlers api.ServerInterface) *Server {
 	}
 }
 
+// errorHandler отвечает на ошибки, которые обработчики не превратили в ответ сами. Ошибки fiber
+// (например, неразобранные параметры) отдаются как есть, остальные логируются и скрываются за 500.
+func errorHandler(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiber.DefaultErrorHandler(c, err)
+	}
+
+	slog.ErrorContext(c.UserContext(), "unhandled error", logging.Error(err))
+
+	return fiber.DefaultErrorHandler(c, fiber.ErrInternalServerError)
+}
+
 func (s *Server) SetSwagger() {
 	openAPISpec, err := os.ReadFile("openapi.yml")
 	if err != nil {
@@ -54,6 +78,11 @@ func (s *Server) SetSwagger() {
 	})
 }
 
+// SetMetrics открывает /metrics для Prometheus.
+func (s *Server) SetMetrics() {
+	s.app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+}
+
 func (s *Server) Start(addr string) error {
 	return s.app.Listen(addr)
 }
@@ -0,0 +1,5 @@
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS last_reviewer_id,
+    DROP COLUMN IF EXISTS reviewer_strategy;
+
+DROP TYPE IF EXISTS reviewer_strategy_enum;
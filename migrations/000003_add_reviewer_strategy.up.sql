@@ -0,0 +1,5 @@
+CREATE TYPE reviewer_strategy_enum AS ENUM('RANDOM', 'ROUND_ROBIN', 'LEAST_LOADED', 'WEIGHTED');
+
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS reviewer_strategy reviewer_strategy_enum NOT NULL DEFAULT 'LEAST_LOADED',
+    ADD COLUMN IF NOT EXISTS last_reviewer_id VARCHAR(36);
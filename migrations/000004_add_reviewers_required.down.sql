@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS reviewers_required;
+
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewers_required;
@@ -0,0 +1,7 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS reviewers_required SMALLINT NOT NULL DEFAULT 2
+        CONSTRAINT valid_team_reviewers_required CHECK (reviewers_required BETWEEN 1 AND 10);
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS reviewers_required SMALLINT NOT NULL DEFAULT 2
+        CONSTRAINT valid_pr_reviewers_required CHECK (reviewers_required BETWEEN 1 AND 10);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_absences_user_id;
+
+DROP TABLE IF EXISTS user_absences;
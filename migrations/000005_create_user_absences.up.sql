@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_absences (
+    id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason VARCHAR(200),
+
+    CONSTRAINT valid_absence_period CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_id ON user_absences (user_id, ends_at);
@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews SMALLINT
+        CONSTRAINT valid_max_open_reviews CHECK (max_open_reviews > 0);
@@ -0,0 +1,19 @@
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS valid_merged_at;
+
+ALTER TYPE status_enum RENAME TO status_enum_old;
+
+CREATE TYPE status_enum AS ENUM('OPEN', 'MERGED');
+
+ALTER TABLE pull_requests
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE status_enum USING status::text::status_enum,
+    ALTER COLUMN status SET DEFAULT 'OPEN';
+
+DROP TYPE status_enum_old;
+
+ALTER TABLE pull_requests ADD CONSTRAINT valid_merged_at CHECK (
+    (status = 'OPEN' AND merged_at IS NULL) OR
+    (status = 'MERGED' AND merged_at IS NOT NULL)
+);
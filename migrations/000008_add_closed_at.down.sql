@@ -0,0 +1,9 @@
+UPDATE pull_requests SET status = 'OPEN', closed_at = NULL WHERE status = 'CLOSED';
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS valid_merged_at;
+ALTER TABLE pull_requests ADD CONSTRAINT valid_merged_at CHECK (
+    (status = 'OPEN' AND merged_at IS NULL) OR
+    (status = 'MERGED' AND merged_at IS NOT NULL)
+);
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS closed_at;
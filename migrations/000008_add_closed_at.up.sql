@@ -0,0 +1,8 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS valid_merged_at;
+ALTER TABLE pull_requests ADD CONSTRAINT valid_merged_at CHECK (
+    (status = 'OPEN' AND merged_at IS NULL AND closed_at IS NULL) OR
+    (status = 'MERGED' AND merged_at IS NOT NULL AND closed_at IS NULL) OR
+    (status = 'CLOSED' AND merged_at IS NULL AND closed_at IS NOT NULL)
+);
@@ -0,0 +1,8 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS require_approvals;
+
+ALTER TABLE reviewers
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS comment,
+    DROP COLUMN IF EXISTS state;
+
+DROP TYPE IF EXISTS review_state_enum;
@@ -0,0 +1,8 @@
+CREATE TYPE review_state_enum AS ENUM('PENDING', 'APPROVED', 'CHANGES_REQUESTED');
+
+ALTER TABLE reviewers
+    ADD COLUMN IF NOT EXISTS state review_state_enum NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN IF NOT EXISTS comment TEXT,
+    ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS require_approvals BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP INDEX IF EXISTS idx_user_logins_user_id;
+
+DROP TABLE IF EXISTS user_logins;
+
+DROP TYPE IF EXISTS vcs_provider_enum;
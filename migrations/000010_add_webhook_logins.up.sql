@@ -0,0 +1,20 @@
+CREATE TYPE vcs_provider_enum AS ENUM('GITHUB');
+
+CREATE TABLE IF NOT EXISTS user_logins (
+    provider vcs_provider_enum NOT NULL,
+    login VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_logins_user_id ON user_logins (user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    provider vcs_provider_enum NOT NULL,
+    delivery_id VARCHAR(100) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (provider, delivery_id)
+);
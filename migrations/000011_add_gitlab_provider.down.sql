@@ -0,0 +1,14 @@
+DELETE FROM user_logins WHERE provider = 'GITLAB';
+DELETE FROM webhook_deliveries WHERE provider = 'GITLAB';
+
+ALTER TYPE vcs_provider_enum RENAME TO vcs_provider_enum_old;
+
+CREATE TYPE vcs_provider_enum AS ENUM('GITHUB');
+
+ALTER TABLE user_logins
+    ALTER COLUMN provider TYPE vcs_provider_enum USING provider::text::vcs_provider_enum;
+
+ALTER TABLE webhook_deliveries
+    ALTER COLUMN provider TYPE vcs_provider_enum USING provider::text::vcs_provider_enum;
+
+DROP TYPE vcs_provider_enum_old;
@@ -0,0 +1 @@
+ALTER TYPE vcs_provider_enum ADD VALUE IF NOT EXISTS 'GITLAB';
@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS source vcs_provider_enum;
+
+UPDATE pull_requests SET source = 'GITHUB' WHERE id LIKE 'github-%';
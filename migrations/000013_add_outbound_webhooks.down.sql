@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_event_deliveries_subscription_id;
+DROP INDEX IF EXISTS idx_event_deliveries_due;
+
+DROP TABLE IF EXISTS event_deliveries;
+
+DROP INDEX IF EXISTS idx_outbox_undispatched;
+
+DROP TABLE IF EXISTS outbox;
+
+DROP TABLE IF EXISTS webhook_subscription_events;
+
+DROP TABLE IF EXISTS webhook_subscriptions;
+
+DROP TYPE IF EXISTS event_delivery_status_enum;
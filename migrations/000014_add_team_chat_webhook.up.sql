@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS chat_webhook_url VARCHAR(2048);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS api_tokens;
+
+DROP TYPE IF EXISTS api_token_role_enum;
@@ -0,0 +1,10 @@
+CREATE TYPE api_token_role_enum AS ENUM('ADMIN', 'USER');
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id INTEGER GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    role api_token_role_enum NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_audit_log_created_at;
+DROP INDEX IF EXISTS idx_audit_log_actor;
+DROP INDEX IF EXISTS idx_audit_log_entity;
+
+DROP TABLE IF EXISTS audit_log;
+
+DROP TYPE IF EXISTS audit_entity_enum;
@@ -0,0 +1,14 @@
+// Package migrations встраивает SQL-миграции в бинарник, чтобы приложение могло применять их само.
+package migrations
+
+import "embed"
+
+// FS содержит миграции Postgres в корне и миграции SQLite в каталоге sqlite.
+//
+//go:embed *.sql sqlite/*.sql
+var FS embed.FS
+
+const (
+	PostgresDir = "."
+	SQLiteDir   = "sqlite"
+)
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_user_absences_user_id;
+
+DROP TABLE IF EXISTS user_absences;
+
+DROP TABLE IF EXISTS reviewers;
+
+DROP TABLE IF EXISTS pull_requests;
+
+DROP INDEX IF EXISTS idx_users_team_id;
+
+DROP TABLE IF EXISTS users;
+
+DROP TABLE IF EXISTS teams;
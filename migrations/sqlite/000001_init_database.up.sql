@@ -0,0 +1,67 @@
+-- Схема SQLite повторяет схему Postgres после всех миграций из migrations/.
+-- Перечисления Postgres заменены на CHECK по списку значений.
+
+CREATE TABLE IF NOT EXISTS teams (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    reviewer_strategy TEXT NOT NULL DEFAULT 'LEAST_LOADED'
+        CONSTRAINT reviewer_strategy_enum
+            CHECK (reviewer_strategy IN ('RANDOM', 'ROUND_ROBIN', 'LEAST_LOADED', 'WEIGHTED')),
+    last_reviewer_id VARCHAR(36),
+    reviewers_required SMALLINT NOT NULL DEFAULT 2
+        CONSTRAINT valid_team_reviewers_required CHECK (reviewers_required BETWEEN 1 AND 10),
+    require_approvals BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    team_id INTEGER NOT NULL REFERENCES teams (id) ON DELETE RESTRICT,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    max_open_reviews SMALLINT
+        CONSTRAINT valid_max_open_reviews CHECK (max_open_reviews > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_team_id ON users (team_id);
+
+CREATE TABLE IF NOT EXISTS pull_requests (
+    id VARCHAR(50) PRIMARY KEY,
+    title VARCHAR(100) NOT NULL,
+    author_id VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE RESTRICT,
+    status TEXT NOT NULL DEFAULT 'OPEN'
+        CONSTRAINT status_enum CHECK (status IN ('OPEN', 'MERGED', 'CLOSED')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    merged_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    reviewers_required SMALLINT NOT NULL DEFAULT 2
+        CONSTRAINT valid_pr_reviewers_required CHECK (reviewers_required BETWEEN 1 AND 10),
+
+    CONSTRAINT valid_merged_at CHECK (
+        (status = 'OPEN' AND merged_at IS NULL AND closed_at IS NULL) OR
+        (status = 'MERGED' AND merged_at IS NOT NULL AND closed_at IS NULL) OR
+        (status = 'CLOSED' AND merged_at IS NULL AND closed_at IS NOT NULL)
+    )
+);
+
+CREATE TABLE IF NOT EXISTS reviewers (
+    pull_request_id VARCHAR(50) REFERENCES pull_requests (id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(36) REFERENCES users (id) ON DELETE CASCADE,
+    state TEXT NOT NULL DEFAULT 'PENDING'
+        CONSTRAINT review_state_enum CHECK (state IN ('PENDING', 'APPROVED', 'CHANGES_REQUESTED')),
+    comment TEXT,
+    reviewed_at TIMESTAMP,
+
+    PRIMARY KEY (reviewer_id, pull_request_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_absences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason VARCHAR(200),
+
+    CONSTRAINT valid_absence_period CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_id ON user_absences (user_id, ends_at);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP INDEX IF EXISTS idx_user_logins_user_id;
+
+DROP TABLE IF EXISTS user_logins;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS user_logins (
+    provider TEXT NOT NULL
+        CONSTRAINT vcs_provider_enum CHECK (provider IN ('GITHUB')),
+    login VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_logins_user_id ON user_logins (user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    provider TEXT NOT NULL
+        CONSTRAINT vcs_provider_enum CHECK (provider IN ('GITHUB')),
+    delivery_id VARCHAR(100) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (provider, delivery_id)
+);
@@ -0,0 +1,35 @@
+ALTER TABLE pull_requests DROP COLUMN source;
+
+CREATE TABLE user_logins_old (
+    provider TEXT NOT NULL
+        CONSTRAINT vcs_provider_enum CHECK (provider IN ('GITHUB')),
+    login VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (provider, login)
+);
+
+INSERT INTO user_logins_old (provider, login, user_id)
+SELECT provider, login, user_id FROM user_logins WHERE provider = 'GITHUB';
+
+DROP INDEX IF EXISTS idx_user_logins_user_id;
+DROP TABLE user_logins;
+ALTER TABLE user_logins_old RENAME TO user_logins;
+
+CREATE INDEX IF NOT EXISTS idx_user_logins_user_id ON user_logins (user_id);
+
+CREATE TABLE webhook_deliveries_old (
+    provider TEXT NOT NULL
+        CONSTRAINT vcs_provider_enum CHECK (provider IN ('GITHUB')),
+    delivery_id VARCHAR(100) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (provider, delivery_id)
+);
+
+INSERT INTO webhook_deliveries_old (provider, delivery_id, action, received_at)
+SELECT provider, delivery_id, action, received_at FROM webhook_deliveries WHERE provider = 'GITHUB';
+
+DROP TABLE webhook_deliveries;
+ALTER TABLE webhook_deliveries_old RENAME TO webhook_deliveries;
@@ -0,0 +1,38 @@
+-- CHECK в SQLite нельзя изменить, поэтому таблицы с провайдером пересоздаются.
+CREATE TABLE user_logins_new (
+    provider TEXT NOT NULL
+        CONSTRAINT vcs_provider_enum CHECK (provider IN ('GITHUB', 'GITLAB')),
+    login VARCHAR(100) NOT NULL,
+    user_id VARCHAR(36) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (provider, login)
+);
+
+INSERT INTO user_logins_new (provider, login, user_id) SELECT provider, login, user_id FROM user_logins;
+
+DROP INDEX IF EXISTS idx_user_logins_user_id;
+DROP TABLE user_logins;
+ALTER TABLE user_logins_new RENAME TO user_logins;
+
+CREATE INDEX IF NOT EXISTS idx_user_logins_user_id ON user_logins (user_id);
+
+CREATE TABLE webhook_deliveries_new (
+    provider TEXT NOT NULL
+        CONSTRAINT vcs_provider_enum CHECK (provider IN ('GITHUB', 'GITLAB')),
+    delivery_id VARCHAR(100) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (provider, delivery_id)
+);
+
+INSERT INTO webhook_deliveries_new (provider, delivery_id, action, received_at)
+SELECT provider, delivery_id, action, received_at FROM webhook_deliveries;
+
+DROP TABLE webhook_deliveries;
+ALTER TABLE webhook_deliveries_new RENAME TO webhook_deliveries;
+
+ALTER TABLE pull_requests ADD COLUMN source TEXT
+    CONSTRAINT vcs_provider_enum CHECK (source IN ('GITHUB', 'GITLAB'));
+
+UPDATE pull_requests SET source = 'GITHUB' WHERE id LIKE 'github-%';
@@ -0,0 +1 @@
+ALTER TABLE teams ADD COLUMN chat_webhook_url VARCHAR(2048);